@@ -0,0 +1,135 @@
+package cloudbet
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// accountCurrencies defines the structure for the account currencies response
+type accountCurrencies struct {
+	Currencies []string `json:"currencies"` // Currency keys held by the account
+}
+
+// listCurrencies retrieves the keys of every currency held by the account
+func (c *APIClient) listCurrencies(ctx context.Context, method string) ([]string, error) {
+	var currencies accountCurrencies // Variable to hold the currencies response
+	if err := c.getCachedJSON(ctx, method, "failed to get currencies", c.accountPath(accountCurrenciesPath), &currencies); err != nil {
+		return nil, err // Return error if the request fails
+	}
+	return currencies.Currencies, nil
+}
+
+// GetAllBalances retrieves the balance of every currency held by the account, keyed by currency.
+// Balances are fetched concurrently, at most WithBatchConcurrency at a time, and zero balances are included
+func (c *APIClient) GetAllBalances(ctx context.Context) (map[string]Money, error) {
+	currencies, err := c.listCurrencies(ctx, "GetAllBalances") // Find out which currencies the account holds
+	if err != nil {
+		return nil, err
+	}
+
+	balances, errs := fanOut(ctx, c.batchConcurrency, currencies, func(ctx context.Context, currency string) (Money, error) {
+		return c.AccountBalance(ctx, currency) // Retrieve the balance of one currency
+	})
+	for _, currency := range currencies {
+		if err := errs[currency]; err != nil {
+			return nil, fmt.Errorf("failed to get balance for %s: %w", currency, err) // Report the first failure in currency order
+		}
+	}
+	return balances, nil
+}
+
+// AccountInfo defines the structure for the account information response
+type AccountInfo struct {
+	Nickname        string           `json:"nickname"`        // Nickname of the account
+	UUID            string           `json:"uuid"`            // Unique ID of the account
+	DefaultCurrency string           `json:"defaultCurrency"` // Default currency of the account
+	Status          string           `json:"status"`          // Status of the account (e.g. ACTIVE)
+	Limits          map[string]Money `json:"limits"`          // Betting limits of the account, keyed by limit name
+
+	Raw map[string]json.RawMessage `json:"-"` // Every field of the response, including ones not modelled above
+}
+
+// UnmarshalJSON decodes the known fields and keeps every field in Raw
+func (a *AccountInfo) UnmarshalJSON(data []byte) error {
+	type accountInfo AccountInfo // Alias without methods to avoid recursion
+	if err := json.Unmarshal(data, (*accountInfo)(a)); err != nil {
+		return err
+	}
+	return json.Unmarshal(data, &a.Raw) // Keep the raw fields so nothing is dropped
+}
+
+// GetAccountInfo retrieves the account information, such as the default currency and limits
+func (c *APIClient) GetAccountInfo(ctx context.Context) (*AccountInfo, error) {
+	var info AccountInfo // Variable to hold the account information response
+	if err := c.getJSON(ctx, "GetAccountInfo", "failed to get account info", c.accountPath(accountInfoPath), &info); err != nil {
+		return nil, err // Return error if the request fails
+	}
+	return &info, nil
+}
+
+// Currency describes a currency held by the account
+type Currency struct {
+	Key  string // Currency key used by the API (e.g. PLAY_EUR, BTC)
+	Name string // Display name of the currency
+	Play bool   // Whether the currency is play money
+}
+
+// currencyNames holds the display names of common Cloudbet currencies
+var currencyNames = map[string]string{
+	"BTC":  "Bitcoin",
+	"ETH":  "Ethereum",
+	"LTC":  "Litecoin",
+	"BCH":  "Bitcoin Cash",
+	"DOGE": "Dogecoin",
+	"USDT": "Tether",
+	"USDC": "USD Coin",
+	"TRX":  "Tron",
+	"XRP":  "Ripple",
+	"SOL":  "Solana",
+	"EUR":  "Euro",
+	"USD":  "US Dollar",
+}
+
+// IsPlayCurrency reports whether a currency key is play money, such as PLAY_EUR
+func IsPlayCurrency(key string) bool {
+	return strings.HasPrefix(strings.ToUpper(key), "PLAY_")
+}
+
+// newCurrency describes a currency key
+func newCurrency(key string) Currency {
+	currency := Currency{Key: key, Name: key, Play: IsPlayCurrency(key)}
+	base := key
+	if currency.Play {
+		base = key[len("PLAY_"):] // Play currencies mirror a real one
+	}
+	if name, ok := currencyNames[strings.ToUpper(base)]; ok {
+		currency.Name = name
+	}
+	if currency.Play {
+		currency.Name = "Play " + currency.Name
+	}
+	return currency
+}
+
+// GetCurrencies retrieves the currencies held by the account, telling play money from real money
+func (c *APIClient) GetCurrencies(ctx context.Context) ([]Currency, error) {
+	keys, err := c.listCurrencies(ctx, "GetCurrencies")
+	if err != nil {
+		return nil, err
+	}
+
+	currencies := make([]Currency, 0, len(keys))
+	for _, key := range keys {
+		currencies = append(currencies, newCurrency(key))
+	}
+	return currencies, nil
+}
+
+// Ping verifies connectivity and the API key with a cheap authenticated request.
+// A rejected key gives an error matching ErrAuthentication, it respects the rate limiter and timeout
+func (c *APIClient) Ping(ctx context.Context) error {
+	_, err := c.getRaw(ctx, "Ping", "ping failed", c.accountPath(accountCurrenciesPath))
+	return err
+}
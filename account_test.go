@@ -0,0 +1,141 @@
+package cloudbet
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// TestGetAllBalances tests that every currency balance is returned, including zero balances
+func TestGetAllBalances(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/pub/v1/account/currencies", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"currencies":["PLAY_EUR","BTC"]}`))
+	})
+	mux.HandleFunc("/pub/v1/account/currencies/PLAY_EUR/balance", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"amount":"1000.25"}`))
+	})
+	mux.HandleFunc("/pub/v1/account/currencies/BTC/balance", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"amount":"0"}`))
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	client := NewAPIClient(apikey, WithBaseURL(server.URL))
+
+	balances, err := client.GetAllBalances(context.Background())
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(balances) != 2 || balances["PLAY_EUR"].String() != "1000.25" {
+		t.Fatalf("unexpected balances %v", balances)
+	}
+	if btc, ok := balances["BTC"]; !ok || !btc.IsZero() {
+		t.Fatalf("expected a zero BTC balance, got %v", balances)
+	}
+}
+
+// TestGetAllBalancesConcurrency tests that balances are fetched at most WithBatchConcurrency at a time
+func TestGetAllBalancesConcurrency(t *testing.T) {
+	var inFlight, peak atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/pub/v1/account/currencies" {
+			w.Write([]byte(`{"currencies":["A","B","C","D","E","F"]}`))
+			return
+		}
+		n := inFlight.Add(1)
+		defer inFlight.Add(-1)
+		for p := peak.Load(); n > p && !peak.CompareAndSwap(p, n); p = peak.Load() {
+		}
+		time.Sleep(5 * time.Millisecond)
+		w.Write([]byte(`{"amount":"1"}`))
+	}))
+	defer server.Close()
+
+	client := NewAPIClient(apikey, WithBaseURL(server.URL), WithBatchConcurrency(2))
+	balances, err := client.GetAllBalances(context.Background())
+	if err != nil || len(balances) != 6 {
+		t.Fatalf("expected 6 balances, got %v, %v", balances, err)
+	}
+	if peak.Load() > 2 {
+		t.Fatalf("expected at most 2 concurrent requests, got %d", peak.Load())
+	}
+}
+
+// TestGetAccountInfo tests that known fields are decoded and unknown ones kept
+func TestGetAccountInfo(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/pub/v1/account/info" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		w.Write([]byte(`{"nickname":"bot","uuid":"u-1","defaultCurrency":"PLAY_EUR","status":"ACTIVE","limits":{"maxStake":"250.5"},"region":"EU"}`))
+	}))
+	defer server.Close()
+
+	client := NewAPIClient(apikey, WithBaseURL(server.URL))
+
+	info, err := client.GetAccountInfo(context.Background())
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if info.DefaultCurrency != "PLAY_EUR" || info.Status != "ACTIVE" || info.Limits["maxStake"].String() != "250.5" {
+		t.Fatalf("unexpected account info %+v", info)
+	}
+	if string(info.Raw["region"]) != `"EU"` {
+		t.Fatalf("expected unknown field to be kept, got %v", info.Raw)
+	}
+}
+
+// TestGetCurrencies tests that play and real currencies are described
+func TestGetCurrencies(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"currencies":["PLAY_EUR","BTC","XYZ"]}`))
+	}))
+	defer server.Close()
+
+	client := NewAPIClient(apikey, WithBaseURL(server.URL))
+
+	currencies, err := client.GetCurrencies(context.Background())
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	want := []Currency{
+		{Key: "PLAY_EUR", Name: "Play Euro", Play: true},
+		{Key: "BTC", Name: "Bitcoin"},
+		{Key: "XYZ", Name: "XYZ"},
+	}
+	if len(currencies) != len(want) {
+		t.Fatalf("expected %v, got %v", want, currencies)
+	}
+	for i := range want {
+		if currencies[i] != want[i] {
+			t.Fatalf("expected %+v, got %+v", want[i], currencies[i])
+		}
+	}
+}
+
+// TestPing tests that Ping succeeds with a valid key and reports a rejected key as an authentication error
+func TestPing(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-API-Key") != apikey {
+			w.WriteHeader(http.StatusUnauthorized)
+			w.Write([]byte(`{"error":"invalid api key"}`))
+			return
+		}
+		w.Write([]byte(`{"currencies":["PLAY_EUR"]}`))
+	}))
+	defer server.Close()
+
+	if err := NewAPIClient(apikey, WithBaseURL(server.URL)).Ping(context.Background()); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	err := NewAPIClient("wrong", WithBaseURL(server.URL)).Ping(context.Background())
+	if !errors.Is(err, ErrAuthentication) {
+		t.Fatalf("expected ErrAuthentication, got %v", err)
+	}
+}
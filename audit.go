@@ -0,0 +1,39 @@
+package cloudbet
+
+import "time"
+
+// AuditRecord describes one bet placement attempt and its outcome
+type AuditRecord struct {
+	Time        time.Time        // Time the attempt started
+	Duration    time.Duration    // Time the attempt took
+	Payload     PlaceBetPayload  // Payload as sent, after the default currency and reference ID were applied, zero for combo bets
+	Combo       *ComboBetPayload // Payload of a combo bet as sent, nil for single bets
+	ReferenceID string           // Reference ID of the bet
+	Status      BetStatus        // Status returned by Cloudbet, empty when no response was decoded
+	Err         error            // Error returned to the caller, nil on success. The API key is redacted
+}
+
+// WithAuditSink calls sink after every bet placement attempt, successful or not, including bets refused
+// before being sent. PlaceBets, PlaceBetSafe and the other placing methods report each submission.
+// The sink is called synchronously from the placing goroutine, so it must be safe for concurrent use
+// and should not block
+func WithAuditSink(sink func(AuditRecord)) Option {
+	return func(c *APIClient) {
+		c.auditSink = sink
+	}
+}
+
+// audit completes the record of a bet placement attempt started at start and reports it to the audit sink, if any
+func (c *APIClient) audit(record AuditRecord, start time.Time, bet *PlaceBetResponse, err error) {
+	if c.auditSink == nil {
+		return
+	}
+	record.Time, record.Duration, record.Err = start, time.Since(start), err
+	if bet != nil {
+		record.Status = bet.Status
+		if bet.ReferenceID != "" {
+			record.ReferenceID = bet.ReferenceID // The reference ID Cloudbet knows the bet by
+		}
+	}
+	c.auditSink(record)
+}
@@ -0,0 +1,93 @@
+package cloudbet
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+)
+
+// TestWithAuditSink tests that accepted, rejected and refused bets are all reported
+func TestWithAuditSink(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var payload PlaceBetPayload
+		json.NewDecoder(r.Body).Decode(&payload)
+		if payload.Stake.IsZero() {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"referenceId":"` + payload.UUID + `","status":"STAKE_TOO_LOW"}`))
+			return
+		}
+		w.Write([]byte(`{"referenceId":"` + payload.UUID + `","status":"ACCEPTED"}`))
+	}))
+	defer server.Close()
+
+	var (
+		mu      sync.Mutex
+		records []AuditRecord
+	)
+	sink := func(record AuditRecord) {
+		mu.Lock()
+		defer mu.Unlock()
+		records = append(records, record)
+	}
+	client := NewAPIClient(apikey, WithBaseURL(server.URL), WithAuditSink(sink), WithDefaultCurrency("PLAY_EUR"), WithPlayMoneyOnly())
+	ctx := context.Background()
+
+	client.PlaceBet(ctx, PlaceBetPayload{UUID: "ref-1", Stake: MustMoney("2")})
+	client.PlaceBet(ctx, PlaceBetPayload{UUID: "ref-2"})
+	client.PlaceBet(ctx, PlaceBetPayload{UUID: "ref-3", Currency: "EUR"})
+
+	if len(records) != 3 {
+		t.Fatalf("expected 3 audit records, got %d", len(records))
+	}
+	accepted, rejected, refused := records[0], records[1], records[2]
+	if accepted.ReferenceID != "ref-1" || accepted.Status != BetStatusAccepted || accepted.Err != nil ||
+		accepted.Payload.Currency != "PLAY_EUR" || accepted.Payload.Stake.String() != "2" || accepted.Time.IsZero() {
+		t.Fatalf("unexpected record of the accepted bet %+v", accepted)
+	}
+	if rejected.ReferenceID != "ref-2" || rejected.Status != BetStatusStakeTooLow || !errors.Is(rejected.Err, ErrStakeTooLow) {
+		t.Fatalf("unexpected record of the rejected bet %+v", rejected)
+	}
+	if refused.ReferenceID != "ref-3" || refused.Status != "" || !errors.Is(refused.Err, ErrRealMoney) {
+		t.Fatalf("unexpected record of the refused bet %+v", refused)
+	}
+}
+
+// TestWithAuditSinkPlaceBets tests that batch payloads refused for their reference ID are reported too
+func TestWithAuditSinkPlaceBets(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var payload PlaceBetPayload
+		json.NewDecoder(r.Body).Decode(&payload)
+		w.Write([]byte(`{"referenceId":"` + payload.UUID + `","status":"ACCEPTED"}`))
+	}))
+	defer server.Close()
+
+	var (
+		mu      sync.Mutex
+		records = make(map[string][]AuditRecord)
+	)
+	sink := func(record AuditRecord) {
+		mu.Lock()
+		defer mu.Unlock()
+		records[record.ReferenceID] = append(records[record.ReferenceID], record)
+	}
+	client := NewAPIClient(apikey, WithBaseURL(server.URL), WithAuditSink(sink), WithDefaultCurrency("PLAY_EUR"))
+
+	_, errs := client.PlaceBets(context.Background(), []PlaceBetPayload{
+		{UUID: "ref-1", Stake: MustMoney("2")},
+		{Stake: MustMoney("2")},
+		{UUID: "ref-1", Stake: MustMoney("3")},
+	})
+	if errs[0] != nil || errs[1] == nil || errs[2] == nil {
+		t.Fatalf("unexpected errors %v", errs)
+	}
+	if missing := records[""]; len(missing) != 1 || missing[0].Err != errs[1] || missing[0].Payload.Currency != "PLAY_EUR" {
+		t.Fatalf("expected the payload without a reference ID to be reported, got %+v", missing)
+	}
+	if reused := records["ref-1"]; len(reused) != 2 {
+		t.Fatalf("expected the accepted and the reused payloads to be reported, got %+v", reused)
+	}
+}
@@ -0,0 +1,63 @@
+package cloudbet
+
+import (
+	"context"
+	"sync"
+)
+
+// DefaultBatchConcurrency is the default number of concurrent requests made by batch methods
+const DefaultBatchConcurrency = 8
+
+// WithBatchConcurrency sets how many requests batch methods such as GetEvents run at once
+func WithBatchConcurrency(n int) Option {
+	return func(c *APIClient) {
+		c.batchConcurrency = n
+	}
+}
+
+// fanOut calls fn for every key using at most concurrency workers, collecting results and errors by key
+func fanOut[K ~string, T any](ctx context.Context, concurrency int, keys []K, fn func(ctx context.Context, key K) (T, error)) (map[K]T, map[K]error) {
+	if concurrency <= 0 {
+		concurrency = DefaultBatchConcurrency // Fall back to the default for unset values
+	}
+
+	var (
+		wg      sync.WaitGroup
+		mu      sync.Mutex
+		results = make(map[K]T, len(keys))
+		errs    = make(map[K]error)
+		jobs    = make(chan K)
+	)
+	for i := 0; i < min(concurrency, len(keys)); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for key := range jobs {
+				result, err := fn(ctx, key)
+				mu.Lock()
+				if err != nil {
+					errs[key] = err // Record the failure of this key only
+				} else {
+					results[key] = result
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+	for _, key := range keys {
+		jobs <- key // Hand out the keys to the workers
+	}
+	close(jobs)
+	wg.Wait()
+
+	return results, errs
+}
+
+// GetEvents retrieves several events concurrently, keyed by ID. Failures are reported per ID
+// in the returned error map, which is empty when every event was fetched. Market keys filter the
+// markets of every event, as for GetEventJSON
+func (c *APIClient) GetEvents(ctx context.Context, ids []string, marketKeys ...string) (map[string]*Event, map[string]error) {
+	return fanOut(ctx, c.batchConcurrency, ids, func(ctx context.Context, id string) (*Event, error) {
+		return c.GetEventJSON(ctx, id, marketKeys...)
+	})
+}
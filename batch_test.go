@@ -0,0 +1,48 @@
+package cloudbet
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// TestGetEvents tests that events are fetched concurrently and failures reported per ID
+func TestGetEvents(t *testing.T) {
+	var running, peak atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := running.Add(1)
+		defer running.Add(-1)
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond) // Keep requests in flight long enough to overlap
+
+		id := strings.TrimPrefix(r.URL.Path, "/pub/v2/odds/events/")
+		if id == "404" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write([]byte(`{"id":` + id + `}`))
+	}))
+	defer server.Close()
+
+	client := NewAPIClient(apikey, WithBaseURL(server.URL), WithBatchConcurrency(3))
+
+	events, errs := client.GetEvents(context.Background(), []string{"1", "2", "3", "4", "5", "404"})
+	if len(events) != 5 || events["4"].ID != 4 {
+		t.Fatalf("unexpected events %v", events)
+	}
+	if len(errs) != 1 || errs["404"] == nil {
+		t.Fatalf("expected one error for 404, got %v", errs)
+	}
+	if peak.Load() > 3 {
+		t.Fatalf("expected at most 3 concurrent requests, got %d", peak.Load())
+	}
+}
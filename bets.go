@@ -0,0 +1,257 @@
+package cloudbet
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// BetHistoryParams defines the filters and pagination of a bet history query, zero values are not sent
+type BetHistoryParams struct {
+	Status   BetStatus // Only return bets with this status (e.g. BetStatusAccepted, BetStatusWin)
+	Currency string    // Only return bets placed in this currency
+	From     time.Time // Only return bets created at or after this time
+	To       time.Time // Only return bets created before this time
+	Limit    int       // Maximum number of bets in the page
+	Offset   int       // Number of bets to skip, use NextOffset of the previous page
+}
+
+// BetHistoryPage defines one page of the bet history
+type BetHistoryPage struct {
+	Bets       []PlaceBetResponse // Bets of this page
+	Total      int                // Total number of bets matching the filters
+	NextOffset int                // Offset of the next page
+	HasMore    bool               // Whether more bets are available after this page
+}
+
+// betHistoryResponse defines the structure for the bet history response
+type betHistoryResponse struct {
+	Bets      []PlaceBetResponse `json:"bets"`      // Bets of the page
+	TotalBets int                `json:"totalBets"` // Total number of bets matching the filters
+}
+
+// GetBetHistory retrieves one page of placed bets matching the given filters
+func (c *APIClient) GetBetHistory(ctx context.Context, params BetHistoryParams) (*BetHistoryPage, error) {
+	query := url.Values{}
+	if params.Status != "" {
+		query.Set("status", string(params.Status))
+	}
+	if params.Currency != "" {
+		query.Set("currency", params.Currency)
+	}
+	if !params.From.IsZero() {
+		query.Set("from", strconv.FormatInt(params.From.Unix(), 10)) // Time filters are unix timestamps
+	}
+	if !params.To.IsZero() {
+		query.Set("to", strconv.FormatInt(params.To.Unix(), 10))
+	}
+	if params.Limit > 0 {
+		query.Set("limit", strconv.Itoa(params.Limit))
+	}
+	if params.Offset > 0 {
+		query.Set("offset", strconv.Itoa(params.Offset))
+	}
+
+	var history betHistoryResponse // Variable to hold the bet history response
+	if err := c.getJSON(ctx, "GetBetHistory", "failed to get bet history", c.tradingPath(betsHistoryPath)+"?"+query.Encode(), &history); err != nil {
+		return nil, err // Return error if the request fails
+	}
+
+	nextOffset := params.Offset + len(history.Bets)
+	return &BetHistoryPage{
+		Bets:       history.Bets,
+		Total:      history.TotalBets,
+		NextOffset: nextOffset,
+		HasMore:    len(history.Bets) > 0 && nextOffset < history.TotalBets,
+	}, nil
+}
+
+// openBetsPageSize is the page size used to walk the bet history for open bets
+const openBetsPageSize = 100
+
+// GetOpenBets retrieves every bet in currency still awaiting settlement, accepted or pending, across
+// all history pages. Their ReturnAmount is the potential return, so summing it gives the outstanding
+// liability. Each bet is returned once, even when the status filter is ignored. An empty currency
+// means every currency
+func (c *APIClient) GetOpenBets(ctx context.Context, currency string) ([]PlaceBetResponse, error) {
+	var open []PlaceBetResponse
+	seen := map[string]bool{} // Reference IDs collected so far
+	for _, status := range []BetStatus{BetStatusAccepted, BetStatusPendingAcceptance} {
+		params := BetHistoryParams{Status: status, Currency: currency, Limit: openBetsPageSize}
+		for {
+			page, err := c.GetBetHistory(ctx, params)
+			if err != nil {
+				return nil, err // Return error if a page fails, a partial list would understate the exposure
+			}
+			for _, bet := range page.Bets {
+				if !bet.Status.IsOpen() {
+					continue // Settled since the filter was applied, or filter ignored
+				}
+				if bet.ReferenceID != "" && seen[bet.ReferenceID] {
+					continue // Already returned by the other status, the filter was ignored or the bet was accepted meanwhile
+				}
+				seen[bet.ReferenceID] = true
+				open = append(open, bet)
+			}
+			if !page.HasMore {
+				break
+			}
+			params.Offset = page.NextOffset
+		}
+	}
+	return open, nil
+}
+
+// Liability is the exposure of the open bets of one currency
+type Liability struct {
+	Total           Money              // Stake at risk across all open bets
+	Accepted        Money              // Stake of bets accepted by Cloudbet
+	Pending         Money              // Stake of bets still pending acceptance, which may yet be rejected
+	PotentialReturn Money              // Amount returned if every open bet wins
+	BySport         map[SportKey]Money // Stake at risk per sport
+}
+
+// GetLiability retrieves the open bets of a currency, an empty currency uses the default currency
+// of the client, and sums their stakes by acceptance and by sport
+func (c *APIClient) GetLiability(ctx context.Context, currency string) (*Liability, error) {
+	if currency == "" {
+		currency = c.defaultCurrency // Fall back to the default currency
+	}
+	if currency == "" {
+		return nil, fmt.Errorf("failed to get liability: no currency given") // Amounts of different currencies cannot be summed
+	}
+
+	bets, err := c.GetOpenBets(ctx, currency)
+	if err != nil {
+		return nil, err
+	}
+
+	liability := &Liability{BySport: make(map[SportKey]Money)}
+	for _, bet := range bets {
+		liability.Total = liability.Total.Add(bet.Stake)
+		if bet.Status == BetStatusPendingAcceptance {
+			liability.Pending = liability.Pending.Add(bet.Stake)
+		} else {
+			liability.Accepted = liability.Accepted.Add(bet.Stake)
+		}
+		potentialReturn := bet.ReturnAmount
+		if potentialReturn.IsZero() {
+			potentialReturn = PotentialReturn(bet.Stake, bet.Price) // Pending bets may not report it yet
+		}
+		liability.PotentialReturn = liability.PotentialReturn.Add(potentialReturn)
+		liability.BySport[bet.SportsKey] = liability.BySport[bet.SportsKey].Add(bet.Stake)
+	}
+	return liability, nil
+}
+
+// TotalLiability returns the stake at risk across the open bets of a currency, accepted or pending,
+// e.g. to enforce a risk cap. Use GetLiability for the breakdown
+func (c *APIClient) TotalLiability(ctx context.Context, currency string) (Money, error) {
+	liability, err := c.GetLiability(ctx, currency)
+	if err != nil {
+		return Money{}, err
+	}
+	return liability.Total, nil
+}
+
+// PlaceBets submits several bets concurrently, each with its own reference ID, and returns the
+// responses and errors aligned with payloads by index. A payload without a reference ID, or
+// reusing one from an earlier payload, is not submitted. Like PlaceBet, nothing is retried
+func (c *APIClient) PlaceBets(ctx context.Context, payloads []PlaceBetPayload) ([]*PlaceBetResponse, []error) {
+	concurrency := c.batchConcurrency
+	if concurrency <= 0 {
+		concurrency = DefaultBatchConcurrency // Fall back to the default for unset values
+	}
+
+	var (
+		wg        sync.WaitGroup
+		responses = make([]*PlaceBetResponse, len(payloads))
+		errs      = make([]error, len(payloads))
+		seen      = make(map[string]bool, len(payloads))
+		slots     = make(chan struct{}, concurrency)
+	)
+	for i, payload := range payloads {
+		start := time.Now()
+		payload = c.withDefaults(payload)
+		switch {
+		case payload.UUID == "":
+			errs[i] = fmt.Errorf("bet %d has no reference ID", i) // Partial success would be ambiguous without one
+		case seen[payload.UUID]:
+			errs[i] = fmt.Errorf("bet %d reuses reference ID %s", i, payload.UUID)
+		}
+		if errs[i] != nil {
+			c.audit(AuditRecord{Payload: payload, ReferenceID: payload.UUID}, start, nil, errs[i]) // Refused bets are reported too
+			continue
+		}
+		seen[payload.UUID] = true
+
+		wg.Add(1)
+		slots <- struct{}{} // Wait for a free slot
+		go func(i int, payload PlaceBetPayload) {
+			defer wg.Done()
+			defer func() { <-slots }()
+			responses[i], errs[i] = c.PlaceBet(ctx, payload) // Each index is written by one goroutine only
+		}(i, payload)
+	}
+	wg.Wait()
+
+	return responses, errs
+}
+
+// placeBetSafeAttempts is the number of submissions made by PlaceBetSafe when WithRetry is not set
+const placeBetSafeAttempts = 3
+
+// PlaceBetSafe places a bet like PlaceBet, but resubmits it when the outcome is ambiguous: after a
+// network error, a timeout, a 5xx response or an unreadable response, GetBetStatus is asked whether the
+// bet landed and it is only sent again when Cloudbet does not know its reference ID. A reference ID is
+// generated when the payload has none, so a bet can never be placed twice. Attempts and delays follow
+// WithRetry, with 3 attempts when it is not set. When the status cannot be determined the original
+// error is returned, carrying the reference ID to check later. A recovered status that is a rejection is
+// returned as an error like in PlaceBet, so errors.Is matches ErrInsufficientFunds and the other sentinels
+func (c *APIClient) PlaceBetSafe(ctx context.Context, payload PlaceBetPayload) (*PlaceBetResponse, error) {
+	payload = c.withDefaults(payload)
+	if payload.UUID == "" {
+		payload.UUID = uuid.NewString() // Every submission must carry the same reference ID
+	}
+
+	policy := c.retry
+	if policy.maxAttempts <= 1 {
+		policy = retryPolicy{maxAttempts: placeBetSafeAttempts, baseDelay: defaultStreamBackoff}
+	}
+	for attempt := 1; ; attempt++ {
+		bet, err := c.PlaceBet(ctx, payload)
+		if err == nil || !ambiguousBetError(err) || ctx.Err() != nil {
+			return bet, err // Return the result if it is known, or can no longer be checked
+		}
+
+		status, statusErr := c.GetBetStatus(ctx, payload.UUID)
+		if statusErr == nil && status.Status.IsRejected() {
+			apiErr := &APIError{StatusCode: http.StatusOK, Code: string(status.Status), Message: c.redact(status.Error)}
+			return status, newBetSubmissionError(payload.UUID, fmt.Errorf("failed to place bet: %w", apiErr)) // The bet reached Cloudbet but was refused
+		}
+		if statusErr == nil {
+			return status, nil // The bet landed despite the error
+		}
+		var apiErr *APIError
+		if !errors.As(statusErr, &apiErr) || apiErr.StatusCode != http.StatusNotFound || attempt >= policy.maxAttempts {
+			return bet, err // Return the original error if the bet may still exist
+		}
+
+		if err := sleepContext(ctx, policy.backoff(attempt)); err != nil {
+			return bet, err // Stop once the context is done
+		}
+	}
+}
+
+// ambiguousBetError reports whether a PlaceBet error leaves open whether the bet was placed
+func ambiguousBetError(err error) bool {
+	var decodeErr *DecodeError
+	return transientError(err) || errors.As(err, &decodeErr) // The response was lost, or the bet forwarded before a failure
+}
@@ -0,0 +1,276 @@
+package cloudbet
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// TestGetBetHistory tests that filters are sent and pagination computed
+func TestGetBetHistory(t *testing.T) {
+	from := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		if r.URL.Path != "/pub/v3/bets/history" || query.Get("status") != "WIN" || query.Get("currency") != "PLAY_EUR" ||
+			query.Get("from") != "1714521600" || query.Get("to") != "" || query.Get("offset") != "2" {
+			t.Errorf("unexpected request %s", r.URL)
+		}
+		w.Write([]byte(`{"bets":[{"referenceId":"a","status":"WIN","returnAmount":"3.8"},{"referenceId":"b","status":"WIN"}],"totalBets":5}`))
+	}))
+	defer server.Close()
+
+	client := NewAPIClient(apikey, WithBaseURL(server.URL))
+
+	page, err := client.GetBetHistory(context.Background(), BetHistoryParams{Status: "WIN", Currency: "PLAY_EUR", From: from, Limit: 2, Offset: 2})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(page.Bets) != 2 || page.Bets[0].ReturnAmount.String() != "3.8" {
+		t.Fatalf("unexpected bets %+v", page.Bets)
+	}
+	if page.NextOffset != 4 || !page.HasMore || page.Total != 5 {
+		t.Fatalf("unexpected pagination %+v", page)
+	}
+}
+
+// TestPlaceBets tests that responses and errors are aligned by index and reference IDs kept apart
+func TestPlaceBets(t *testing.T) {
+	client, closeServer := newTestClient(nil, WithBatchConcurrency(2))
+	defer closeServer()
+
+	payload := func(referenceID string, price string) PlaceBetPayload {
+		return PlaceBetPayload{Currency: "PLAY_EUR", EventId: "24055338", MarketURL: "soccer.match_odds/home", Price: MustMoney(price), Stake: MustMoney("10"), UUID: referenceID}
+	}
+	payloads := []PlaceBetPayload{
+		payload("a", "1.91"),
+		payload("", "1.91"),
+		payload("b", "3.6"),
+		payload("a", "1.91"),
+	}
+
+	responses, errs := client.PlaceBets(context.Background(), payloads)
+	if len(responses) != 4 || len(errs) != 4 {
+		t.Fatalf("expected results aligned with 4 payloads, got %d and %d", len(responses), len(errs))
+	}
+	if errs[0] != nil || responses[0].ReferenceID != "a" {
+		t.Fatalf("expected bet a to be placed, got %+v, %v", responses[0], errs[0])
+	}
+	if errs[2] != nil || responses[2].ReferenceID != "b" || responses[2].ReturnAmount.String() != "36" {
+		t.Fatalf("expected bet b to be placed, got %+v, %v", responses[2], errs[2])
+	}
+	if errs[1] == nil || responses[1] != nil {
+		t.Fatalf("expected the bet without reference ID to be rejected, got %+v", responses[1])
+	}
+	if errs[3] == nil || responses[3] != nil {
+		t.Fatalf("expected the duplicate reference ID to be rejected, got %+v", responses[3])
+	}
+}
+
+// TestGetOpenBets tests that every page of both open statuses is collected and settled bets dropped
+func TestGetOpenBets(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		if query.Get("currency") != "PLAY_EUR" {
+			t.Errorf("unexpected request %s", r.URL)
+		}
+		switch query.Get("status") + "/" + query.Get("offset") {
+		case "ACCEPTED/":
+			w.Write([]byte(`{"bets":[{"referenceId":"a","status":"ACCEPTED","returnAmount":"19.1"}],"totalBets":2}`))
+		case "ACCEPTED/1":
+			w.Write([]byte(`{"bets":[{"referenceId":"b","status":"WIN","returnAmount":"5"}],"totalBets":2}`))
+		case "PENDING_ACCEPTANCE/":
+			w.Write([]byte(`{"bets":[{"referenceId":"c","status":"PENDING_ACCEPTANCE","returnAmount":"36"}],"totalBets":1}`))
+		default:
+			t.Errorf("unexpected request %s", r.URL)
+		}
+	}))
+	defer server.Close()
+
+	client := NewAPIClient(apikey, WithBaseURL(server.URL))
+
+	bets, err := client.GetOpenBets(context.Background(), "PLAY_EUR")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(bets) != 2 || bets[0].ReferenceID != "a" || bets[1].ReferenceID != "c" {
+		t.Fatalf("unexpected open bets %+v", bets)
+	}
+	if liability := bets[0].ReturnAmount.Add(bets[1].ReturnAmount); liability.String() != "55.1" {
+		t.Fatalf("expected a liability of 55.1, got %s", liability)
+	}
+}
+
+// TestGetOpenBetsIgnoredFilter tests that each open bet is returned once when the status filter is ignored
+func TestGetOpenBetsIgnoredFilter(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"bets":[{"referenceId":"a","status":"ACCEPTED"},{"referenceId":"b","status":"WIN"},{"referenceId":"c","status":"PENDING_ACCEPTANCE"}],"totalBets":3}`))
+	}))
+	defer server.Close()
+
+	client := NewAPIClient(apikey, WithBaseURL(server.URL))
+
+	bets, err := client.GetOpenBets(context.Background(), "PLAY_EUR")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(bets) != 2 || bets[0].ReferenceID != "a" || bets[1].ReferenceID != "c" {
+		t.Fatalf("expected each open bet once, got %+v", bets)
+	}
+}
+
+// TestGetLiability tests that open stakes are summed by acceptance and sport
+func TestGetLiability(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Query().Get("status") {
+		case "ACCEPTED":
+			w.Write([]byte(`{"bets":[
+				{"referenceId":"a","status":"ACCEPTED","stake":"10","price":"1.91","returnAmount":"19.1","sportsKey":"soccer"},
+				{"referenceId":"b","status":"ACCEPTED","stake":"2.5","price":"2","returnAmount":"5","sportsKey":"tennis"}],"totalBets":2}`))
+		default:
+			w.Write([]byte(`{"bets":[{"referenceId":"c","status":"PENDING_ACCEPTANCE","stake":"4","price":"3","sportsKey":"soccer"}],"totalBets":1}`))
+		}
+	}))
+	defer server.Close()
+
+	client := NewAPIClient(apikey, WithBaseURL(server.URL), WithDefaultCurrency("PLAY_EUR"))
+
+	liability, err := client.GetLiability(context.Background(), "")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if liability.Total.String() != "16.5" || liability.Accepted.String() != "12.5" || liability.Pending.String() != "4" {
+		t.Fatalf("unexpected liability %+v", liability)
+	}
+	if liability.PotentialReturn.String() != "36.1" || liability.BySport["soccer"].String() != "14" || liability.BySport["tennis"].String() != "2.5" {
+		t.Fatalf("unexpected liability breakdown %+v", liability)
+	}
+
+	if total, err := client.TotalLiability(context.Background(), "PLAY_EUR"); err != nil || total.String() != "16.5" {
+		t.Fatalf("expected a total liability of 16.5, got %s, %v", total, err)
+	}
+	if _, err := NewAPIClient(apikey, WithBaseURL(server.URL)).TotalLiability(context.Background(), ""); err == nil {
+		t.Fatalf("expected an error without a currency")
+	}
+}
+
+// TestGetLiabilityIgnoredFilter tests that an ignored status filter does not inflate the liability
+func TestGetLiabilityIgnoredFilter(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"bets":[{"referenceId":"a","status":"ACCEPTED","stake":"10","price":"1.91","returnAmount":"19.1","sportsKey":"soccer"}],"totalBets":1}`))
+	}))
+	defer server.Close()
+
+	client := NewAPIClient(apikey, WithBaseURL(server.URL))
+
+	liability, err := client.GetLiability(context.Background(), "PLAY_EUR")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if liability.Total.String() != "10" || liability.PotentialReturn.String() != "19.1" || liability.BySport["soccer"].String() != "10" {
+		t.Fatalf("expected the single stake to be counted once, got %+v", liability)
+	}
+	if total, err := client.TotalLiability(context.Background(), "PLAY_EUR"); err != nil || total.String() != "10" {
+		t.Fatalf("expected a total liability of 10, got %s, %v", total, err)
+	}
+}
+
+// TestPlaceBetSafe tests that ambiguous failures are resolved through the bet status before resubmitting
+func TestPlaceBetSafe(t *testing.T) {
+	var (
+		mu        sync.Mutex
+		placed    = map[string]bool{}
+		submitted []string
+		failures  int  // Number of submissions to fail
+		storeFail bool // Whether failed submissions still place the bet
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		if r.Method == "POST" {
+			var payload PlaceBetPayload
+			json.NewDecoder(r.Body).Decode(&payload)
+			submitted = append(submitted, payload.UUID)
+			if payload.Stake.String() == "0" {
+				w.WriteHeader(http.StatusBadRequest)
+				w.Write([]byte(`{"status":"STAKE_TOO_LOW"}`))
+				return
+			}
+			if len(submitted) <= failures {
+				if storeFail {
+					placed[payload.UUID] = true
+				}
+				w.WriteHeader(http.StatusBadGateway)
+				return
+			}
+			placed[payload.UUID] = true
+			w.Write([]byte(`{"referenceId":"` + payload.UUID + `","status":"ACCEPTED"}`))
+			return
+		}
+		referenceID := strings.Split(r.URL.Path, "/")[4]
+		if !placed[referenceID] {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write([]byte(`{"referenceId":"` + referenceID + `","status":"PENDING_ACCEPTANCE"}`))
+	}))
+	defer server.Close()
+
+	client := NewAPIClient(apikey, WithBaseURL(server.URL), WithRetry(3, time.Millisecond))
+	payload := PlaceBetPayload{EventId: "1", MarketURL: "soccer.match_odds/home", Currency: "PLAY_EUR", Price: MustMoney("1.9"), Stake: MustMoney("1")}
+
+	// The first submission is lost, the bet is sent again with the same reference ID
+	failures = 1
+	bet, err := client.PlaceBetSafe(context.Background(), payload)
+	if err != nil || bet.Status != BetStatusAccepted {
+		t.Fatalf("expected the resubmitted bet to be accepted, got %+v, %v", bet, err)
+	}
+	if len(submitted) != 2 || submitted[0] == "" || submitted[0] != submitted[1] {
+		t.Fatalf("expected two submissions with one generated reference ID, got %v", submitted)
+	}
+
+	// The first submission fails after placing the bet, it is not sent again
+	submitted, storeFail = nil, true
+	bet, err = client.PlaceBetSafe(context.Background(), payload)
+	if err != nil || bet.Status != BetStatusPendingAcceptance || len(submitted) != 1 {
+		t.Fatalf("expected the placed bet without resubmission, got %+v, %v after %d submissions", bet, err, len(submitted))
+	}
+
+	// Rejections are final
+	submitted, payload.Stake = nil, MustMoney("0")
+	if _, err := client.PlaceBetSafe(context.Background(), payload); !errors.Is(err, ErrStakeTooLow) || len(submitted) != 1 {
+		t.Fatalf("expected the rejection without resubmission, got %v after %d submissions", err, len(submitted))
+	}
+}
+
+// TestPlaceBetSafeRejectedStatus tests that a rejection recovered through the bet status is returned as an error
+func TestPlaceBetSafeRejectedStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "POST" {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		w.Write([]byte(`{"referenceId":"ref","status":"INSUFFICIENT_FUNDS","error":"no funds"}`))
+	}))
+	defer server.Close()
+
+	client := NewAPIClient(apikey, WithBaseURL(server.URL))
+	payload := PlaceBetPayload{EventId: "1", MarketURL: "soccer.match_odds/home", Currency: "PLAY_EUR", Price: MustMoney("1.9"), Stake: MustMoney("1"), UUID: "ref"}
+
+	bet, err := client.PlaceBetSafe(context.Background(), payload)
+	if !errors.Is(err, ErrInsufficientFunds) {
+		t.Fatalf("expected ErrInsufficientFunds, got %v", err)
+	}
+	var submitErr *BetSubmissionError
+	if !errors.As(err, &submitErr) || submitErr.ReferenceID != "ref" {
+		t.Fatalf("expected a BetSubmissionError carrying the reference ID, got %v", err)
+	}
+	if bet == nil || bet.Status != BetStatusInsufficientFunds {
+		t.Fatalf("expected the recovered status, got %+v", bet)
+	}
+}
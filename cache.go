@@ -0,0 +1,86 @@
+package cloudbet
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// responseCache holds the responses of reference data requests, keyed by path
+type responseCache struct {
+	ttl     time.Duration // How long a response is served from the cache
+	mu      sync.Mutex    // Guards entries
+	entries map[string]cacheEntry
+}
+
+// cacheEntry is a cached response and its expiry
+type cacheEntry struct {
+	raw     *RawResponse // Response as received
+	expires time.Time    // Time after which the response is fetched again
+}
+
+// WithCache caches the responses of GetSports, GetCompetitions (and the other sport queries such as
+// GetCategories) and GetCurrencies for ttl, keyed by their parameters. The cache is safe for concurrent
+// use and can be emptied with InvalidateCache. Odds, events, balances and bets are never cached.
+// Zero or a negative ttl disables the cache, the default
+func WithCache(ttl time.Duration) Option {
+	return func(c *APIClient) {
+		c.cache = nil
+		if ttl > 0 {
+			c.cache = &responseCache{ttl: ttl, entries: make(map[string]cacheEntry)}
+		}
+	}
+}
+
+// InvalidateCache removes every response cached by WithCache, so the next calls fetch fresh data
+func (c *APIClient) InvalidateCache() {
+	if c.cache == nil {
+		return
+	}
+	c.cache.mu.Lock()
+	defer c.cache.mu.Unlock()
+	clear(c.cache.entries)
+}
+
+// get returns the cached response of path unless it expired
+func (rc *responseCache) get(path string) (*RawResponse, bool) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	entry, ok := rc.entries[path]
+	if !ok {
+		return nil, false
+	}
+	if time.Now().After(entry.expires) {
+		delete(rc.entries, path) // Drop the stale response
+		return nil, false
+	}
+	return entry.raw, true
+}
+
+// put caches the response of path for the TTL
+func (rc *responseCache) put(path string, raw *RawResponse) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	rc.entries[path] = cacheEntry{raw: raw, expires: time.Now().Add(rc.ttl)}
+}
+
+// getCachedJSON is getJSON for reference data, served from the cache when WithCache is set.
+// Responses are decoded on every call, so callers never share the decoded values
+func (c *APIClient) getCachedJSON(ctx context.Context, method, op, path string, v any) error {
+	if c.cache == nil {
+		return c.getJSON(ctx, method, op, path, v)
+	}
+	if raw, ok := c.cache.get(path); ok {
+		return c.decodeJSON(op, raw, v)
+	}
+
+	raw, err := c.getRaw(ctx, method, op, path)
+	if err != nil {
+		return err // Return error if the request fails, failures are not cached
+	}
+	if err := c.decodeJSON(op, raw, v); err != nil {
+		return err // Return error if the response is not JSON, it is not cached either
+	}
+	c.cache.put(path, raw)
+	return nil
+}
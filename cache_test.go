@@ -0,0 +1,77 @@
+package cloudbet
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+// TestWithCache tests that reference data is cached per path until it expires or is invalidated
+func TestWithCache(t *testing.T) {
+	var (
+		mu       sync.Mutex
+		requests = map[string]int{}
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		requests[r.URL.Path]++
+		mu.Unlock()
+		switch r.URL.Path {
+		case "/pub/v2/odds/sports":
+			w.Write([]byte(`{"sports":[{"key":"soccer","name":"Soccer"}]}`))
+		case "/pub/v1/account/currencies":
+			w.Write([]byte(`{"currencies":["PLAY_EUR"]}`))
+		case "/pub/v1/account/currencies/PLAY_EUR/balance":
+			w.Write([]byte(`{"amount":"10"}`))
+		default:
+			w.Write([]byte(`{"key":"soccer","categories":[{"key":"england","competitions":[{"key":"soccer-england-premier-league"}]}]}`))
+		}
+	}))
+	defer server.Close()
+
+	client := NewAPIClient(apikey, WithBaseURL(server.URL), WithCache(50*time.Millisecond))
+	ctx := context.Background()
+
+	var wg sync.WaitGroup
+	for range 3 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			client.GetSports(ctx) // Concurrent calls must be safe
+		}()
+	}
+	wg.Wait()
+	for range 2 {
+		if sports, err := client.GetSports(ctx); err != nil || len(sports) != 1 {
+			t.Fatalf("unexpected sports %+v, %v", sports, err)
+		}
+		client.GetCompetitions(ctx, "soccer")
+		client.GetCompetitions(ctx, "tennis")
+		client.GetCurrencies(ctx)
+		client.AccountBalance(ctx, "PLAY_EUR")
+	}
+
+	mu.Lock()
+	if requests["/pub/v2/odds/sports/soccer"] != 1 || requests["/pub/v2/odds/sports/tennis"] != 1 || requests["/pub/v1/account/currencies"] != 1 {
+		t.Errorf("expected one request per cached path, got %v", requests)
+	}
+	if requests["/pub/v1/account/currencies/PLAY_EUR/balance"] != 2 {
+		t.Errorf("expected balances not to be cached, got %v", requests)
+	}
+	sportsRequests := requests["/pub/v2/odds/sports"]
+	mu.Unlock()
+
+	client.InvalidateCache()
+	client.GetSports(ctx)
+	time.Sleep(60 * time.Millisecond)
+	client.GetSports(ctx)
+
+	mu.Lock()
+	defer mu.Unlock()
+	if requests["/pub/v2/odds/sports"] != sportsRequests+2 {
+		t.Fatalf("expected invalidation and expiry to fetch the sports again, got %d requests after %d", requests["/pub/v2/odds/sports"], sportsRequests)
+	}
+}
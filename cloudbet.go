@@ -1,122 +1,299 @@
 package cloudbet
 
 import (
+	"context"
 	"encoding/json"
 	"net/http"
-	"strconv"
 	"bytes"
 	"time"
 	"fmt"
 	"io"
+	"net/url"
+	"sync"
+
+	"github.com/google/uuid"
+	"golang.org/x/time/rate"
 )
 
-// APIClient is the struct for the Cloudbet API client
+// APIClient is the struct for the Cloudbet API client.
+// Its methods are safe for concurrent use by multiple goroutines, internal state is guarded
+// by a mutex. The exported fields must not be changed once the client is in use
 type APIClient struct {
 	BaseURL	string // Base URL for the Cloudbet API
 	APIKey	string // API key for authentication
 	Client	*http.Client // HTTP client with a timeout
-}
 
-// NewAPIClient initializes a new Cloudbet API client
-func NewAPIClient(apiKey string) *APIClient {
-	return &APIClient{
-		BaseURL:	"https://sports-api.cloudbet.com", // Set the base URL for the API
+	retry	retryPolicy // Retry configuration for idempotent requests
+	limiter	*rate.Limiter // Client side rate limiter, nil when disabled
+	batchConcurrency	int // Number of concurrent requests made by batch methods
+	userAgent	string // User-Agent sent with every request
+	headers		http.Header // Extra headers sent with every request
+	maxResponseBytes	int64 // Maximum size of a response body, zero or negative for no limit
+	cache		*responseCache // Cache of reference data responses, nil when disabled
+	insecureSkipVerify	bool // WithInsecureSkipVerify was given and not replaced by a later transport
+	auditSink	func(AuditRecord) // Receives every bet placement attempt, nil when disabled
+	locale		string // Language of the names returned, empty for the default
+	logger	Logger // Receives request traces, nil when disabled
+	defaultCurrency	string // Currency used when none is given
+	autoReferenceID	bool // Generate reference IDs for bets placed without one
+	playMoneyOnly	bool // Reject bets in real currencies
+	accountVersion	string // Version of the account endpoints, empty for the default
+	oddsVersion	string // Version of the odds feed endpoints, empty for the default
+	tradingVersion	string // Version of the betting endpoints, empty for the default
+	requestHooks	[]func(*http.Request) // Called with every request before it is sent
+	responseHooks	[]func(*http.Response) // Called with every response when it is received
+	metrics		Collector // Receives a measurement for every call, nil when disabled
+	streamIdleTimeout	time.Duration // Reconnect odds streams silent for this long, zero to wait forever
+	webhookSecret	[]byte // Key of the webhook signatures, nil when not configured
+
+	mu		sync.Mutex // Guards the fields below
+	lastResponse	ResponseMetadata // Metadata of the most recent response
+	lastTiming	RequestTiming // Timing of the most recent call whose response was read
+	closed		bool // Set by Close
+	done		chan struct{} // Closed by Close to stop background goroutines
+}
+
+// NewAPIClient initializes a new Cloudbet API client, configured by the given options
+func NewAPIClient(apiKey string, opts ...Option) *APIClient {
+	c := &APIClient{
+		BaseURL:	DefaultBaseURL, // Set the base URL for the API
 		APIKey:		apiKey, // Assign the provided API key
-		Client:		&http.Client{Timeout: 10 * time.Second}, // Create a new HTTP client with a timeout
+		Client:		&http.Client{Timeout: DefaultTimeout, Transport: newDefaultTransport()}, // Create a new HTTP client with a timeout and pooled connections
+		batchConcurrency:	DefaultBatchConcurrency, // Limit the concurrency of batch methods
+		userAgent:	DefaultUserAgent, // Identify the library to Cloudbet
+		maxResponseBytes:	DefaultMaxResponseBytes, // Bound the memory used by a response
 	}
+	for _, opt := range opts {
+		opt(c) // Apply each option in order
+	}
+	c.warnInsecure() // Warn about unverified certificates once the options are applied
+	return c
+}
+
+// contextError wraps ctx.Err() with the failed operation when the request was cancelled or timed out
+func contextError(ctx context.Context, op string, err error) error {
+	if ctxErr := ctx.Err(); ctxErr != nil {
+		return fmt.Errorf("%s: %w", op, ctxErr) // Prefer the context error so callers can match context.Canceled
+	}
+	return err // Otherwise return the original transport error
+}
+
+// PriceChangePolicy defines which price changes are accepted when a bet is placed
+type PriceChangePolicy string
+
+const (
+	PriceChangeNone   PriceChangePolicy = "NONE"   // Reject the bet if the price changed
+	PriceChangeBetter PriceChangePolicy = "BETTER" // Accept only price changes in the bettor's favour
+	PriceChangeAll    PriceChangePolicy = "ALL"    // Accept any price change
+)
+
+// BetType defines the flavour of a bet, it selects the endpoint and the required fields
+type BetType string
+
+const (
+	BetTypeLine     BetType = "LINE"     // Standard fixed-odds bet, the default
+	BetTypeExchange BetType = "EXCHANGE" // Exchange bet backing or laying a selection, requires a Side
+)
+
+// BetSide defines whether an exchange bet backs or lays a selection
+type BetSide string
+
+const (
+	SideBack BetSide = "BACK" // Bet on the selection to win
+	SideLay  BetSide = "LAY"  // Bet against the selection
+)
+
+// betPlacePaths maps each bet type to the betting endpoint it is placed on, Cloudbet currently
+// accepts both on the same endpoint and tells them apart by the side
+var betPlacePaths = map[BetType]string{
+	BetTypeLine:     betsPlacePath,
+	BetTypeExchange: betsPlacePath,
 }
 
 // PlaceBetPayload defines the payload for placing a bet
 type PlaceBetPayload struct {
-	PriceChange		string	`json:"acceptPriceChange"` // Indicates if price changes are accepted
+	Type			BetType	`json:"-"` // Type of the bet, empty means BetTypeLine
+	Side			BetSide	`json:"side,omitempty"` // Side of an exchange bet
+	PriceChange		PriceChangePolicy	`json:"acceptPriceChange"` // Indicates which price changes are accepted
 	Currency		string	`json:"currency"` // Currency for the bet
 	EventId			string	`json:"eventId"` // ID of the event to bet on
 	MarketURL		string	`json:"marketUrl"` // URL of the market for the bet
-	Price			string	`json:"price"` // Price at which to place the bet
+	Price			Money	`json:"price"` // Price at which to place the bet
 	UUID			string	`json:"referenceId"` // Unique reference ID for the bet
-	Stake			string	`json:"stake"` // Amount to stake on the bet
+	Stake			Money	`json:"stake"` // Amount to stake on the bet
 }
 
 // PlaceBetResponse defines the structure of the response after placing a bet
 type PlaceBetResponse struct {
 	ReferenceID       string `json:"referenceId"` // Reference ID of the placed bet
-	Price             string `json:"price"` // Price at which the bet was placed
+	Price             Money  `json:"price"` // Price at which the bet was placed
 	EventID           string `json:"eventId"` // ID of the event
 	MarketURL         string `json:"marketUrl"` // URL of the market
 	Side              string `json:"side"` // Side of the bet (e.g., home/away)
 	Currency          string `json:"currency"` // Currency of the bet
-	Stake             string `json:"stake"` // Amount staked
+	Stake             Money  `json:"stake"` // Amount staked
 	CreateTime        string `json:"createTime"` // Time the bet was created
-	Status            string `json:"status"` // Status of the bet (e.g., pending, settled)
-	ReturnAmount      string `json:"returnAmount"` // Potential return amount
+	Status            BetStatus `json:"status"` // Status of the bet (e.g., accepted, settled)
+	ReturnAmount      Money  `json:"returnAmount"` // Potential return amount
 	EventName         string `json:"eventName"` // Name of the event
-	SportsKey         string `json:"sportsKey"` // Key for the sport
+	SportsKey         SportKey `json:"sportsKey"` // Key for the sport
 	CompetitionID     string `json:"competitionId"` // ID of the competition
 	CategoryKey       string `json:"categoryKey"` // Key for the category
 	CustomerReference string `json:"customerReference"` // Customer's reference for the bet
 	Error             string `json:"error"` // Error message if any
 }
 
-// PlaceBet submits a bet to the Cloudbet API
-func (c *APIClient) PlaceBet(payload PlaceBetPayload) (*PlaceBetResponse, error) {
+// betPlacePath validates the type specific fields of a payload and returns the endpoint to place it on
+func betPlacePath(payload PlaceBetPayload) (string, error) {
+	betType := payload.Type
+	if betType == "" {
+		betType = BetTypeLine // Bets are line bets unless stated otherwise
+	}
+
+	path, ok := betPlacePaths[betType]
+	if !ok {
+		return "", fmt.Errorf("unknown bet type %q", payload.Type) // Return error if the type is not supported
+	}
+	switch {
+	case betType == BetTypeLine && payload.Side != "" && payload.Side != SideBack:
+		return "", fmt.Errorf("line bets can only back a selection, got side %q", payload.Side)
+	case betType == BetTypeExchange && payload.Side != SideBack && payload.Side != SideLay:
+		return "", fmt.Errorf("exchange bets require side %s or %s, got %q", SideBack, SideLay, payload.Side)
+	}
+	return path, nil
+}
+
+// withDefaults fills the fields of a payload left empty with the client defaults
+func (c *APIClient) withDefaults(payload PlaceBetPayload) PlaceBetPayload {
+	if payload.Currency == "" {
+		payload.Currency = c.defaultCurrency // Explicit currencies always win
+	}
+	if payload.UUID == "" && c.autoReferenceID {
+		payload.UUID = uuid.NewString() // Generate the reference ID so the bet can always be tracked
+	}
+	return payload
+}
+
+// checkCurrency returns an error when bets in currency are not allowed by WithPlayMoneyOnly
+func (c *APIClient) checkCurrency(currency string) error {
+	if c.playMoneyOnly && !IsPlayCurrency(currency) {
+		return fmt.Errorf("failed to place bet in %q: %w", currency, ErrRealMoney)
+	}
+	return nil
+}
+
+// PlaceBet submits a bet to the Cloudbet API, the request is bound to ctx
+func (c *APIClient) PlaceBet(ctx context.Context, payload PlaceBetPayload) (*PlaceBetResponse, error) {
+	start := time.Now()
+	payload = c.withDefaults(payload) // Fill in the default currency
+	bet, err := c.placeBet(ctx, "PlaceBet", payload)
+	c.audit(AuditRecord{Payload: payload, ReferenceID: payload.UUID}, start, bet, err) // Report every attempt, failures included
+	return bet, err
+}
+
+// placeBet submits a bet whose defaults were already applied
+func (c *APIClient) placeBet(ctx context.Context, method string, payload PlaceBetPayload) (*PlaceBetResponse, error) {
+	if err := c.checkCurrency(payload.Currency); err != nil {
+		return nil, err // Return error if real money is not allowed
+	}
+
+	path, err := betPlacePath(payload) // Pick the endpoint for the bet type
+	if err != nil {
+		return nil, err // Return error if the type specific fields are invalid
+	}
+	return c.submitBet(ctx, method, path, payload.UUID, payload)
+}
+
+// submitBet posts a bet payload to the trading path and decodes the response, referenceID is the
+// reference ID sent with the payload
+func (c *APIClient) submitBet(ctx context.Context, method, path, referenceID string, payload any) (*PlaceBetResponse, error) {
 	body, err := json.Marshal(payload) // Convert the payload to JSON
 	if err != nil {
 		return nil, err // Return error if marshaling fails
 	}
 
 	// Create a new POST request to place the bet
-	req, err := http.NewRequest("POST", c.BaseURL+"/pub/v3/bets/place", bytes.NewBuffer(body))
+	req, err := c.newRequest(ctx, "POST", c.tradingPath(path), bytes.NewBuffer(body))
 	if err != nil {
 		return nil, err // Return error if request creation fails
 	}
-	req.Header.Set("X-API-Key", c.APIKey) // Set the API key in the header
-	req.Header.Set("Content-Type", "application/json") // Set content type to JSON
-	req.Header.Set("accept", "application/json") // Set accept header for JSON response
 
-	resp, err := c.Client.Do(req) // Send the request
+	// The bet may have reached Cloudbet from here on, errors carry the reference ID to check its status with
+	sent := &PlaceBetResponse{ReferenceID: referenceID}
+	resp, err := c.do(method, req, false) // Send the request, placing a bet is never retried
 	if err != nil {
-		return nil, err // Return error if request fails
+		return sent, newBetSubmissionError(referenceID, c.redactError(contextError(ctx, "failed to place bet", err))) // Return error if request fails
 	}
 	defer resp.Body.Close() // Ensure the response body is closed after processing
 
-	var plabeBet PlaceBetResponse // Variable to hold the response
-	if err := json.NewDecoder(resp.Body).Decode(&plabeBet); err != nil {
-		return nil, err // Return error if decoding fails
+	respBody, err := io.ReadAll(resp.Body) // Read the response body
+	if err != nil {
+		return sent, newBetSubmissionError(referenceID, c.redactError(contextError(ctx, "failed to place bet", readError(err)))) // Return error if reading body fails
 	}
 
+	var plabeBet PlaceBetResponse // Variable to hold the response
 	if resp.StatusCode != http.StatusOK {
-		return &plabeBet, fmt.Errorf("failed to place bet: %s", resp.Status) // Return error if status is not OK
+		apiErr := newAPIError(resp, respBody) // Parse the rejection returned by Cloudbet
+		apiErr.Message = c.redact(apiErr.Message) // The body may echo request details
+		json.Unmarshal(respBody, &plabeBet) // Decode what we can of the response, it may not be JSON
+		plabeBet.Error = c.redact(plabeBet.Error) // The body may echo request details
+		if plabeBet.Error == "" {
+			plabeBet.Error = apiErr.Message // Keep the Error field populated for existing callers
+		}
+		if plabeBet.ReferenceID == "" {
+			plabeBet.ReferenceID = referenceID // Rejections do not always echo the reference ID
+		}
+		return &plabeBet, newBetSubmissionError(referenceID, fmt.Errorf("failed to place bet: %w", apiErr)) // Return error if status is not OK
+	}
+
+	raw := &RawResponse{StatusCode: resp.StatusCode, Header: resp.Header, Body: respBody, Timing: responseTiming(resp)}
+	if err := c.decodeJSON("failed to place bet", raw, &plabeBet); err != nil {
+		return sent, newBetSubmissionError(referenceID, err) // Return error if the response is empty or not JSON
+	}
+	if apiErr := newAPIError(resp, respBody); plabeBet.Status.IsRejected() || isRejectionCode(apiErr.Code) {
+		apiErr.Message = c.redact(apiErr.Message) // The body may echo request details
+		plabeBet.Error = c.redact(plabeBet.Error)
+		if plabeBet.ReferenceID == "" {
+			plabeBet.ReferenceID = referenceID // Rejections do not always echo the reference ID
+		}
+		return &plabeBet, newBetSubmissionError(referenceID, fmt.Errorf("failed to place bet: %w", apiErr)) // Return error if the bet was rejected with a 200
+	}
+	if referenceID != "" && plabeBet.ReferenceID != "" && plabeBet.ReferenceID != referenceID {
+		mismatch := &MismatchError{Sent: referenceID, Received: plabeBet.ReferenceID}
+		return &plabeBet, newBetSubmissionError(referenceID, fmt.Errorf("failed to place bet: %w", mismatch)) // Return error if the response is about another bet
 	}
 
 	return &plabeBet, nil // Return the response if successful
 }
 
+// GetBetStatus retrieves the current state of a placed bet by its reference ID
+func (c *APIClient) GetBetStatus(ctx context.Context, referenceId string) (*PlaceBetResponse, error) {
+	var bet PlaceBetResponse // Variable to hold the bet status
+	path := c.tradingPath(fmt.Sprintf(betsStatusPath, url.PathEscape(referenceId)))
+	if err := c.getJSON(ctx, "GetBetStatus", "failed to get bet status", path, &bet); err != nil {
+		return nil, err // Return error if the request fails
+	}
+	return &bet, nil // Return the bet status
+}
+
 // Balance defines the structure for account balance response
 type Balance struct {
-	Amount string `json:"amount"` // Amount of balance
+	Amount Money `json:"amount"` // Amount of balance
 }
 
-// AccountBalance retrieves the user's account balance for a specific currency
-func (c *APIClient) AccountBalance(currency string) (float64, error) {
-	// Create a new GET request to retrieve account balance
-	req, err := http.NewRequest("GET", c.BaseURL+fmt.Sprintf("/pub/v1/account/currencies/%s/balance", currency), nil)
-	if err != nil {
-		return 0, err // Return error if request creation fails
+// AccountBalance retrieves the user's account balance for a specific currency, an empty
+// currency uses the default currency of the client
+func (c *APIClient) AccountBalance(ctx context.Context, currency string) (Money, error) {
+	if currency == "" {
+		currency = c.defaultCurrency // Fall back to the default currency
 	}
-	req.Header.Set("X-API-Key", c.APIKey) // Set the API key in the header
-
-	resp, err := c.Client.Do(req) // Send the request
-	if err != nil {
-		return 0, err // Return error if request fails
-	}
-	defer resp.Body.Close() // Ensure the response body is closed after processing
-
 	var balance Balance // Variable to hold the balance response
-	if err := json.NewDecoder(resp.Body).Decode(&balance); err != nil {
-		return 0, err // Return error if decoding fails
+	path := c.accountPath(fmt.Sprintf(accountBalancePath, url.PathEscape(currency)))
+	if err := c.getJSON(ctx, "AccountBalance", "failed to get account balance", path, &balance); err != nil {
+		return Money{}, err // Return error if the request fails or the status is not OK
 	}
 
-	return strconv.ParseFloat(balance.Amount, 64) // Convert balance amount to float64 and return
+	return balance.Amount, nil // Return the exact balance amount
 }
 
 // Fixtures defines the structure for upcoming fixtures response
@@ -126,8 +303,10 @@ type Fixtures struct {
 
 // Sport defines the structure for sport details
 type Sport struct {
-	Name string `json:"name"` // Name of the sport
-	Key  string `json:"key"` // Key for the sport
+	Name             string `json:"name"` // Name of the sport
+	Key              SportKey `json:"key"` // Key for the sport
+	EventCount       int    `json:"eventCount,omitempty"` // Number of events currently offered, only set by GetSports
+	CompetitionCount int    `json:"competitionCount,omitempty"` // Number of competitions, only set by GetSports
 }
 
 // Home defines the structure for home team details
@@ -152,21 +331,18 @@ type Away struct {
 type Players struct {
 }
 
-// Markets defines the structure for market details (currently empty)
-type Markets struct {
-}
-
-// Events defines the structure for event details
+// Events defines the structure for event details as listed in fixtures and competitions, a summary of an
+// Event. Use Detailed to fetch the full Event of a fixture
 type Events struct {
 	ID         int       `json:"id"` // ID of the event
 	Home       Home      `json:"home"` // Home team details
 	Away       Away      `json:"away"` // Away team details
 	Players    Players   `json:"players"` // Player details
-	Status     string    `json:"status"` // Status of the event
-	Markets    Markets   `json:"markets"` // Market details
+	Status     EventStatus `json:"status"` // Status of the event
+	Markets    map[string]Market `json:"markets"` // Market details keyed by market key, when requested
 	Name       string    `json:"name"` // Name of the event
-	Key        string    `json:"key"` // Key for the event
-	CutoffTime time.Time `json:"cutoffTime"` // Cutoff time for the event
+	Key        EventKey  `json:"key"` // Key for the event
+	CutoffTime CloudbetTime `json:"cutoffTime"` // Cutoff time for the event
 	Type       string    `json:"type"` // Type of the event
 }
 
@@ -179,70 +355,82 @@ type Category struct {
 // Competitions defines the structure for competition details
 type Competitions struct {
 	Name     string   `json:"name"` // Name of the competition
-	Key      string   `json:"key"` // Key for the competition
+	Key      CompetitionKey `json:"key"` // Key for the competition
 	Sport    Sport    `json:"sport"` // Sport details
 	Events   []Events `json:"events"` // List of events in the competition
 	Category Category `json:"category"` // Category details
 }
 
 // GetTodayFixtures retrieves upcoming sports fixtures for a specific sport, clear body
-func (c *APIClient) GetTodayFixtures(sport string, limit int) (string, error) {
-	// Create a new GET request to retrieve today's fixtures for the specified sport
-	req, err := http.NewRequest("GET", c.BaseURL+fmt.Sprintf("/pub/v2/odds/fixtures?sport=%s&date=%s&players=false&limit=%d", sport, fmt.Sprint(time.Now().Format("2006-01-02")), limit), nil)
+func (c *APIClient) GetTodayFixtures(ctx context.Context, sport SportKey, limit int) (string, error) {
+	raw, err := c.getFixturesByDate(ctx, "GetTodayFixtures", sport, time.Now(), limit) // Retrieve the fixtures for today
 	if err != nil {
-		return "", err // Return error if request creation fails
+		return "", err // Return error if the request fails or the status is not OK
 	}
 
-	req.Header.Set("X-API-Key", c.APIKey) // Set the API key in the header
-	req.Header.Set("accept", "application/json") // Set accept header for JSON response
-
-	resp, err := c.Client.Do(req) // Send the request
-	if err != nil {	
-		return "", err // Return error if request fails
-	}
-	defer resp.Body.Close() // Ensure the response body is closed after processing
+	return string(raw.Body), nil // Return the response body as a string
+}
 
-	body, err := io.ReadAll(resp.Body) // Read the response body
+// GetFixturesByDate retrieves sports fixtures for a specific sport on the given day, clear body
+func (c *APIClient) GetFixturesByDate(ctx context.Context, sport SportKey, date time.Time, limit int) (string, error) {
+	raw, err := c.getFixturesByDate(ctx, "GetFixturesByDate", sport, date, limit) // Retrieve the fixtures of the day for the specified sport
 	if err != nil {
-		return "", err // Return error if reading body fails
+		return "", err // Return error if the request fails or the status is not OK
 	}
 
-	return string(body), nil // Return the response body as a string
+	return string(raw.Body), nil // Return the response body as a string
 }
+
+// getFixturesByDate retrieves the raw fixtures of a sport on the given day
+func (c *APIClient) getFixturesByDate(ctx context.Context, method string, sport SportKey, date time.Time, limit int) (*RawResponse, error) {
+	return c.getRaw(ctx, method, "failed to get fixtures", c.oddsPath(oddsFixturesPath)+fmt.Sprintf("?sport=%s&date=%s&players=false&limit=%d", url.QueryEscape(string(sport)), date.Format("2006-01-02"), limit))
+}
+
 // GetFixtures retrieves upcoming sports fixtures for a specific sport in JSON format
-func (c *APIClient) GetTodayFixturesJSON(sport string, limit int) (*Fixtures, error) {
-	jsonBody, err := c.GetTodayFixtures(sport, limit) // Call to retrieve today's fixtures for the specified sport
+func (c *APIClient) GetTodayFixturesJSON(ctx context.Context, sport SportKey, limit int) (*Fixtures, error) {
+	return c.fixturesByDateJSON(ctx, "GetTodayFixturesJSON", sport, time.Now(), limit) // Retrieve the fixtures for today
+}
+
+// GetFixturesByDateJSON retrieves sports fixtures for a specific sport on the given day in JSON format
+func (c *APIClient) GetFixturesByDateJSON(ctx context.Context, sport SportKey, date time.Time, limit int) (*Fixtures, error) {
+	return c.fixturesByDateJSON(ctx, "GetFixturesByDateJSON", sport, date, limit)
+}
+
+// fixturesByDateJSON retrieves and decodes the fixtures of a sport on the given day for method
+func (c *APIClient) fixturesByDateJSON(ctx context.Context, method string, sport SportKey, date time.Time, limit int) (*Fixtures, error) {
+	raw, err := c.getFixturesByDate(ctx, method, sport, date, limit) // Call to retrieve the fixtures of the day for the specified sport
 	if err != nil {
 		return nil, err // Return error if the function fails to retrieve fixtures
 	}
 
 	var fixtures Fixtures // Variable to hold the parsed fixtures response
-	err = json.Unmarshal([]	byte(jsonBody), &fixtures) // Unmarshal JSON response into the fixtures variable
+	err = c.decodeJSON("failed to get fixtures", raw, &fixtures) // Unmarshal JSON response into the fixtures variable
 	if err != nil {
-		return nil, err // Return error if JSON unmarshalling fails
+		return nil, err // Return error if the response is empty or not JSON
 	}
 
 	return &fixtures, nil // Return the parsed fixtures response
 }
 
-// Event represents a sports event with various attributes
+// Event represents a sports event with various attributes, as returned by GetEventJSON. Fixtures list
+// the same events as the lighter Events
 type Event struct {
 	Away            EventAway   	`json:"away"` // Away team details
 	Competition     Competition 	`json:"competition"` // Competition details
-	CutoffTime      time.Time   	`json:"cutoffTime"` // Cutoff time for the event
-	EndTime         time.Time   	`json:"endTime"` // End time of the event
+	CutoffTime      CloudbetTime	`json:"cutoffTime"` // Cutoff time for the event
+	EndTime         CloudbetTime	`json:"endTime"` // End time of the event, zero until known
 	GradingDuration int         	`json:"gradingDuration"` // Duration for grading the event
 	Home            EventHome   	`json:"home"` // Home team details
 	ID              int         	`json:"id"` // Unique identifier for the event
-	Key             string      	`json:"key"` // Key for the event
-	Markets         EventMarkets	`json:"markets"` // Betting markets associated with the event
+	Key             EventKey    	`json:"key"` // Key for the event
+	Markets         map[string]Market	`json:"markets"` // Betting markets associated with the event, keyed by market key
 	Metadata        Metadata    	`json:"metadata"` // Additional metadata for the event
 	Name            string      	`json:"name"` // Name of the event
-	ResultedTime    time.Time   	`json:"resultedTime"` // Time when the event result was recorded
+	ResultedTime    CloudbetTime	`json:"resultedTime"` // Time when the event result was recorded, zero until resulted
 	Sequence        int         	`json:"sequence"` // Sequence number of the event
 	Settlement      Settlement  	`json:"settlement"` // Settlement details for the event
 	EventSport           Sport     	`json:"sport"` // Sport type of the event
-	Status          string      	`json:"status"` // Current status of the event
+	Status          EventStatus 	`json:"status"` // Current status of the event
 	Type            int         	`json:"type"` // Type of the event
 }
 
@@ -263,7 +451,7 @@ type EventCategory struct {
 // Competition represents details of a sports competition
 type Competition struct {
 	Category Category `json:"category"` // Category of the competition
-	Key      string   `json:"key"` // Key for the competition
+	Key      CompetitionKey `json:"key"` // Key for the competition
 	Name     string   `json:"name"` // Name of the competition
 }
 
@@ -284,37 +472,20 @@ type Selections struct {
 	Price       float64 `json:"price"` // Price of the selection
 	Probability float64 `json:"probability"` // Probability of the outcome
 	Side        string  `json:"side"` // Side of the selection (e.g., home or away)
-	Status      string  `json:"status"` // Status of the selection
-}
-
-// AdditionalProp1, AdditionalProp2, AdditionalProp3 represent additional properties for selections
-type AdditionalProp1 struct {
-	Selections []Selections `json:"selections"` // List of selections
-	Sequence   int          `json:"sequence"` // Sequence number for the property
-}
-
-type AdditionalProp2 struct {
-	Layout string `json:"layout"`
-	Scores string `json:"scores"`
-}
+	Status      SelectionStatus `json:"status"` // Status of the selection
 
-type AdditionalProp3 struct {
-	Layout string `json:"layout"`
-	Scores string `json:"scores"`
+	exact selectionAmounts // Price and stake limits exactly as sent, see PriceMoney
 }
 
-// Submarkets represent various submarkets for betting
-type Submarkets struct {
-	AdditionalProp1 AdditionalProp1 `json:"additionalProp1"` // First additional property
-	AdditionalProp2 AdditionalProp2 `json:"additionalProp2"` // Second additional property
-	AdditionalProp3 AdditionalProp3 `json:"additionalProp3"` // Third additional property
+// Submarket represents a submarket (e.g. a period) of a market with its selections
+type Submarket struct {
+	Selections []Selections `json:"selections"` // List of selections
+	Sequence   int          `json:"sequence"` // Sequence number of the submarket
 }
 
-// Markets represent different betting markets available for an event
-type EventMarkets struct {
-	AdditionalProp1 AdditionalProp1 `json:"additionalProp1"` // First additional property
-	AdditionalProp2 AdditionalProp2 `json:"additionalProp2"` // Second additional property
-	AdditionalProp3 AdditionalProp3 `json:"additionalProp3"` // Third additional property
+// Market represents a betting market, keyed by submarket key (e.g. "period=ft")
+type Market struct {
+	Submarkets map[string]Submarket `json:"submarkets"` // Submarkets of the market
 }
 
 // Opinion represents an opinion on a market
@@ -333,67 +504,112 @@ type Categories struct {
 	Probability float64 `json:"probability"` // Probability associated with the category
 }
 
-// Opinions represent a collection of opinions
-type Opinions struct {
-	AdditionalProp1 AdditionalProp1 `json:"additionalProp1"` // First additional property
-	AdditionalProp2 AdditionalProp2 `json:"additionalProp2"` // Second additional property
-	AdditionalProp3 AdditionalProp3 `json:"additionalProp3"` // Third additional property
-}
-
 // Metadata contains additional information about an event
 type Metadata struct {
 	Opinion  []Opinion `json:"opinion"` // List of opinions on the event
-	Opinions Opinions  `json:"opinions"` // Collection of opinions
+
+	Raw map[string]json.RawMessage `json:"-"` // Every metadata field, including ones not modelled above
+}
+
+// UnmarshalJSON decodes the known fields and keeps every field in Raw
+func (m *Metadata) UnmarshalJSON(data []byte) error {
+	type metadata Metadata // Alias without methods to avoid recursion
+	if err := json.Unmarshal(data, (*metadata)(m)); err != nil {
+		return err
+	}
+	return json.Unmarshal(data, &m.Raw) // Keep the raw fields so nothing is dropped
+}
+
+// MarshalJSON encodes the fields kept in Raw along with the known fields, so metadata survives a round trip
+func (m Metadata) MarshalJSON() ([]byte, error) {
+	if m.Raw == nil && m.Opinion == nil {
+		return []byte("null"), nil // Nothing was received
+	}
+	fields := make(map[string]json.RawMessage, len(m.Raw)+1)
+	for key, value := range m.Raw {
+		fields[key] = value
+	}
+	if m.Opinion != nil {
+		opinion, err := json.Marshal(m.Opinion)
+		if err != nil {
+			return nil, err
+		}
+		fields["opinion"] = opinion // The known field wins over a stale raw copy
+	}
+	return json.Marshal(fields)
+}
+
+// Settlement represents the settlement details for an event, keyed by market key
+type Settlement map[string]MarketSettlement
+
+// MarketSettlement represents the settlement of a market, keyed by submarket key
+type MarketSettlement struct {
+	Submarkets map[string]SubmarketSettlement `json:"submarkets"` // Settled submarkets of the market
 }
 
-// Settlement represents the settlement details for an event
-type Settlement struct {
-	AdditionalProp1 AdditionalProp1 `json:"additionalProp1"` // First additional property
-	AdditionalProp2 AdditionalProp2 `json:"additionalProp2"` // Second additional property
-	AdditionalProp3 AdditionalProp3 `json:"additionalProp3"` // Third additional property
+// SubmarketSettlement represents the results of the selections of a submarket
+type SubmarketSettlement struct {
+	Selections []SelectionResult `json:"selections"` // Results of the selections
+}
+
+// SelectionResult represents the result of a single selection
+type SelectionResult struct {
+	Outcome string `json:"outcome"` // Outcome of the selection
+	Params  string `json:"params"` // Additional parameters for the selection
+	Result  string `json:"result"` // Result of the selection (e.g. WIN, LOSS, PUSH)
 }
 
 // Sport represents a sport type
 type EventSport struct {
-	Key  string `json:"key"` // Key for the sport
+	Key  SportKey `json:"key"` // Key for the sport
 	Name string `json:"name"` // Name of the sport
 }
 
 // GetEvent retrieves a specific event by its ID
-func (c *APIClient) GetEvent(id string) (string, error) {
-	// Create a new GET request to retrieve event details by its ID
-	req, err := http.NewRequest("GET", c.BaseURL+fmt.Sprintf("/pub/v2/odds/events/%s", id), nil)
+func (c *APIClient) GetEvent(ctx context.Context, id string) (string, error) {
+	raw, err := c.getEvent(ctx, "GetEvent", id) // Retrieve event details by its ID
 	if err != nil {
-		return "", err // Return error if request creation fails
+		return "", err // Return error if the request fails or the status is not OK
 	}
-	req.Header.Set("X-API-Key", c.APIKey) // Set the API key in the request header
 
-	resp, err := c.Client.Do(req) // Send the request to the server
-	if err != nil {
-		return "", err // Return error if the request fails
-	}
-	defer resp.Body.Close() // Ensure the response body is closed after processing
+	return string(raw.Body), nil // Return the response body as a string
+}
 
-	bodyBytes, err := io.ReadAll(resp.Body) // Read the response body
-	if err != nil {
-		return "", err // Return error if reading the body fails
+// getEvent retrieves the raw details of an event by its ID, with only the given markets when any are given
+func (c *APIClient) getEvent(ctx context.Context, method, id string, marketKeys ...string) (*RawResponse, error) {
+	path := c.oddsPath(fmt.Sprintf(oddsEventPath, url.PathEscape(id)))
+	if len(marketKeys) > 0 {
+		query := url.Values{}
+		for _, marketKey := range marketKeys {
+			query.Add("markets", marketKey) // Only send the requested markets
+		}
+		path += "?" + query.Encode()
 	}
-
-	return string(bodyBytes), nil // Return the response body as a string
+	return c.getRaw(ctx, method, "failed to get event", path)
 }
 
-// GetEventJSON retrieves a specific event in JSON format by its ID
-func (c *APIClient) GetEventJSON(id string) (*Event, error) {
-	jsonBody, err := c.GetEvent(id) // Call to retrieve event details
+// GetEventJSON retrieves a specific event in JSON format by its ID. Pass market keys (e.g. "soccer.match_odds")
+// to include only those markets and keep the payload small, all markets are included when none are given
+func (c *APIClient) GetEventJSON(ctx context.Context, id string, marketKeys ...string) (*Event, error) {
+	raw, err := c.getEvent(ctx, "GetEventJSON", id, marketKeys...) // Call to retrieve event details
 	if err != nil {
 		return nil, err // Return error if the function fails
 	}
 
 	var event Event // Variable to hold the parsed event response
-	err = json.Unmarshal([]byte(jsonBody), &event) // Unmarshal JSON response into the event variable
+	err = c.decodeJSON("failed to get event", raw, &event) // Unmarshal JSON response into the event variable
 	if err != nil {
-		return nil, err // Return error if JSON unmarshalling fails
+		return nil, err // Return error if the response is empty or not JSON
 	}
 
 	return &event, nil // Return the parsed event response
 }
+
+// GetEventByKey retrieves a specific event by its key (e.g. "c-arsenal-v-c-chelsea") instead of its numeric ID
+func (c *APIClient) GetEventByKey(ctx context.Context, key EventKey) (*Event, error) {
+	var event Event // Variable to hold the parsed event response
+	if err := c.getJSON(ctx, "GetEventByKey", "failed to get event", c.oddsPath(fmt.Sprintf(oddsEventByKeyPath, url.PathEscape(string(key)))), &event); err != nil {
+		return nil, err // Return error if the request or decoding fails
+	}
+	return &event, nil
+}
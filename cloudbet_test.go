@@ -1,7 +1,16 @@
 package cloudbet
 
 import (
+	"bytes" // Import the bytes package to compare encoded payloads
+	"context" // Import the context package for request contexts
+	"encoding/json" // Import the json package for payload checks
+	"errors" // Import the errors package for error matching
+	"net/http" // Import the http package for test handlers
+	"net/http/httptest" // Import the httptest package for local test servers
+	"os" // Import the os package to read the live API key
+	"sync" // Import the sync package for concurrent tests
 	"testing" // Import the testing package for writing tests
+	"time" // Import the time package for timeouts
 
 	"github.com/google/uuid" // Import the uuid package for generating unique identifiers
 )
@@ -9,24 +18,36 @@ import (
 // API key for authenticating with the Cloudbet API
 const apikey string = "APIKEY"
 
+// newTestAPIClient returns a client for the live API when CLOUDBET_API_KEY is set,
+// otherwise a client for the canned test server
+func newTestAPIClient(t *testing.T) *APIClient {
+	if key := os.Getenv("CLOUDBET_API_KEY"); key != "" {
+		return NewAPIClient(key) // Run against the real API
+	}
+
+	client, closeServer := newTestClient(nil) // Run offline against the canned fixtures
+	t.Cleanup(closeServer)
+	return client
+}
+
 // TestPlaceBet tests the PlaceBet method of the API client
 func TestPlaceBet(t *testing.T) {
-	// Create a new API client with the provided API key
-	client := NewAPIClient(apikey)
+	// Create a new API client for the live API or the test server
+	client := newTestAPIClient(t)
 
 	// Prepare the payload for placing a bet
 	payload := PlaceBetPayload{
-		PriceChange:	"ALL", // Accept all price changes
+		PriceChange:	PriceChangeAll, // Accept all price changes
 		EventId:		"24055338", // ID of the event to bet on
 		MarketURL:		"soccer.match_odds/away", // Market URL for the bet
-		Price:			"1.50", // Price at which to place the bet
-		Stake:			"1", // Amount to stake
+		Price:			MustMoney("1.50"), // Price at which to place the bet
+		Stake:			MustMoney("1"), // Amount to stake
 		Currency:		"PLAY_EUR", // Currency for the bet
 		UUID:			uuid.New().String(), // Generate a new unique identifier for the bet
 	}
 
 	// Call the PlaceBet method and capture the response
-	bet, err := client.PlaceBet(payload)
+	bet, err := client.PlaceBet(context.Background(), payload)
 	if err != nil {
 		t.Log(bet.Error) // Log the bet details if there is an error
 		t.Fatalf("expected no error, got %v", err) // Fail the test if an error occurred
@@ -37,11 +58,11 @@ func TestPlaceBet(t *testing.T) {
 
 // TestAccountBalance tests the AccountBalance method of the API client
 func TestAccountBalance(t *testing.T) {
-	// Create a new API client with the provided API key
-	client := NewAPIClient(apikey)
+	// Create a new API client for the live API or the test server
+	client := newTestAPIClient(t)
 
 	// Call the AccountBalance method to retrieve the balance for a specific currency
-	balance, err := client.AccountBalance("EUR")
+	balance, err := client.AccountBalance(context.Background(), "EUR")
 	if err != nil {
 		t.Fatalf("expected no error, got %v", err) // Fail the test if an error occurred
 	}
@@ -52,11 +73,11 @@ func TestAccountBalance(t *testing.T) {
 
 // TestGetTodayFixtures tests the GetTodayFixtures method of the API client
 func TestGetTodayFixtures(t *testing.T) {
-	// Create a new API client with the provided API key
-	client := NewAPIClient(apikey)
+	// Create a new API client for the live API or the test server
+	client := newTestAPIClient(t)
 
 	// Call the GetTodayFixtures method to retrieve today's fixtures for soccer
-	fixtures, err := client.GetTodayFixtures("soccer", 1000)
+	fixtures, err := client.GetTodayFixtures(context.Background(), "soccer", 1000)
 	if err != nil {
 		t.Fatalf("expected no error, got %v", err) // Fail the test if an error occurred
 	}
@@ -67,11 +88,11 @@ func TestGetTodayFixtures(t *testing.T) {
 
 // TestGetEvent tests the GetEvent method of the API client
 func TestGetEvent(t *testing.T) {
-	// Create a new API client with the provided API key
-	client := NewAPIClient(apikey)
+	// Create a new API client for the live API or the test server
+	client := newTestAPIClient(t)
 
 	// Call the GetEvent method to get event details
-	event, err := client.GetEvent("12345678")
+	event, err := client.GetEvent(context.Background(), "12345678")
 	if err != nil {
 		t.Fatalf("expected no error, got %v", err) // Fail the test if an error occurred
 	}
@@ -79,3 +100,313 @@ func TestGetEvent(t *testing.T) {
 	// Log the retrieved fixtures
 	t.Logf("%+v", event)
 }
+
+// TestContextCancelled tests that a cancelled context aborts the request and is reported
+func TestContextCancelled(t *testing.T) {
+	// Create a server that blocks until the client goes away
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	}))
+	defer server.Close()
+
+	client := NewAPIClient(apikey, WithBaseURL(server.URL)) // Point the client at the test server
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	// Call the GetEvent method with a context that expires mid-flight
+	_, err := client.GetEvent(ctx, "12345678")
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected context.DeadlineExceeded, got %v", err) // Fail the test if the context error was lost
+	}
+}
+
+// TestGetBetStatus tests the GetBetStatus method of the API client
+func TestGetBetStatus(t *testing.T) {
+	// Create a server that returns a settled bet
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/pub/v3/bets/ref-1/status" {
+			t.Errorf("unexpected path %s", r.URL.Path) // Report requests to the wrong endpoint
+		}
+		w.Write([]byte(`{"referenceId":"ref-1","status":"WIN","returnAmount":"2.5"}`))
+	}))
+	defer server.Close()
+
+	client := NewAPIClient(apikey, WithBaseURL(server.URL)) // Point the client at the test server
+
+	// Call the GetBetStatus method for the placed bet
+	bet, err := client.GetBetStatus(context.Background(), "ref-1")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err) // Fail the test if an error occurred
+	}
+	if bet.Status != "WIN" || bet.ReturnAmount.String() != "2.5" {
+		t.Fatalf("unexpected bet %+v", bet) // Fail the test if the fields were not decoded
+	}
+}
+
+// TestGetEventJSONMarkets tests that GetEventJSON parses markets, submarkets and selections
+func TestGetEventJSONMarkets(t *testing.T) {
+	// Create a client for the canned test server
+	client, closeServer := newTestClient(nil)
+	defer closeServer()
+
+	// Call the GetEventJSON method to get the parsed event
+	event, err := client.GetEventJSON(context.Background(), "24055338")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err) // Fail the test if an error occurred
+	}
+
+	selections := event.Markets["soccer.match_odds"].Submarkets["period=ft"].Selections
+	if len(selections) != 3 {
+		t.Fatalf("expected 3 selections, got %d", len(selections)) // Fail the test if the markets were dropped
+	}
+	if selections[0].Outcome != "home" || selections[0].Price != 1.91 {
+		t.Fatalf("unexpected selection %+v", selections[0]) // Fail the test if the selection was not decoded
+	}
+}
+
+// TestGetEventByKey tests that GetEventByKey resolves an event through the key based endpoint
+func TestGetEventByKey(t *testing.T) {
+	// Create a server that checks the requested key
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/pub/v2/odds/events/key/c-arsenal-v-c-chelsea" {
+			t.Errorf("unexpected path %s", r.URL.Path) // Report requests for the wrong endpoint
+		}
+		w.Write([]byte(`{"id":24055338,"key":"c-arsenal-v-c-chelsea","markets":{"soccer.match_odds":{"submarkets":{}}}}`))
+	}))
+	defer server.Close()
+
+	client := NewAPIClient(apikey, WithBaseURL(server.URL))
+
+	// Call the GetEventByKey method to get the parsed event
+	event, err := client.GetEventByKey(context.Background(), "c-arsenal-v-c-chelsea")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err) // Fail the test if an error occurred
+	}
+	if event.ID != 24055338 || event.Key != "c-arsenal-v-c-chelsea" {
+		t.Fatalf("unexpected event %+v", event) // Fail the test if the event was not decoded
+	}
+	if _, ok := event.Markets["soccer.match_odds"]; !ok {
+		t.Fatalf("expected the markets to be decoded") // Fail the test if the markets were dropped
+	}
+}
+
+// TestGetEventJSONMarketFilter tests that GetEventJSON only asks for the given markets, and for all without any
+func TestGetEventJSONMarketFilter(t *testing.T) {
+	// Create a server that records the requested markets
+	var markets [][]string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		markets = append(markets, r.URL.Query()["markets"])
+		w.Write([]byte(`{"id":24055338,"markets":{"soccer.match_odds":{"submarkets":{}}}}`))
+	}))
+	defer server.Close()
+
+	client := NewAPIClient(apikey, WithBaseURL(server.URL))
+
+	// Call the GetEventJSON method with and without a filter
+	if _, err := client.GetEventJSON(context.Background(), "24055338", "soccer.match_odds", "soccer.total_goals"); err != nil {
+		t.Fatalf("expected no error, got %v", err) // Fail the test if an error occurred
+	}
+	if _, err := client.GetEventJSON(context.Background(), "24055338"); err != nil {
+		t.Fatalf("expected no error, got %v", err) // Fail the test if an error occurred
+	}
+
+	if len(markets) != 2 || len(markets[0]) != 2 || markets[0][0] != "soccer.match_odds" || markets[0][1] != "soccer.total_goals" {
+		t.Fatalf("unexpected market filter %v", markets) // Fail the test if the markets were not sent
+	}
+	if len(markets[1]) != 0 {
+		t.Fatalf("expected no market filter, got %v", markets[1]) // Fail the test if a filter was sent without markets
+	}
+}
+
+// TestGetFixturesByDate tests that GetFixturesByDate requests the given day
+func TestGetFixturesByDate(t *testing.T) {
+	// Create a server that checks the requested date
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if date := r.URL.Query().Get("date"); date != "2024-05-19" {
+			t.Errorf("expected date 2024-05-19, got %s", date) // Report requests for the wrong day
+		}
+		w.Write([]byte(`{"competitions":[{"name":"Premier League","key":"soccer-england-premier-league","events":[{"id":24055338}]}]}`))
+	}))
+	defer server.Close()
+
+	client := NewAPIClient(apikey, WithBaseURL(server.URL)) // Point the client at the test server
+
+	// Call the GetFixturesByDateJSON method for a fixed day
+	fixtures, err := client.GetFixturesByDateJSON(context.Background(), "soccer", time.Date(2024, 5, 19, 12, 0, 0, 0, time.UTC), 10)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err) // Fail the test if an error occurred
+	}
+	if len(fixtures.Competitions) != 1 || fixtures.Competitions[0].Events[0].ID != 24055338 {
+		t.Fatalf("unexpected fixtures %+v", fixtures) // Fail the test if the fixtures were not decoded
+	}
+}
+
+// TestPriceChangePolicyJSON tests that the policy marshals to the value Cloudbet expects
+func TestPriceChangePolicyJSON(t *testing.T) {
+	// Marshal a payload using one of the policies
+	body, err := json.Marshal(PlaceBetPayload{PriceChange: PriceChangeBetter})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err) // Fail the test if an error occurred
+	}
+
+	var fields map[string]any // Variable to hold the decoded payload
+	if err := json.Unmarshal(body, &fields); err != nil {
+		t.Fatalf("expected no error, got %v", err) // Fail the test if an error occurred
+	}
+	if fields["acceptPriceChange"] != "BETTER" {
+		t.Fatalf("expected BETTER, got %v", fields["acceptPriceChange"]) // Fail the test if the policy was not encoded
+	}
+}
+
+// TestConcurrentGetEvent tests that one client can be shared by many goroutines, run it with -race
+func TestConcurrentGetEvent(t *testing.T) {
+	// Share one client with retry and rate limiting enabled
+	client, closeServer := newTestClient(nil, WithRetry(2, time.Millisecond), WithRateLimit(1000, 50))
+	defer closeServer()
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 50) // Collect the errors of every goroutine
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := client.GetEventJSON(context.Background(), "24055338"); err != nil {
+				errs <- err
+			}
+			client.LastResponse() // Read the shared metadata while other goroutines write it
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		t.Fatalf("expected no error, got %v", err) // Fail the test if any call failed
+	}
+}
+
+// TestRequestHeaders tests that every method sends the same mandatory headers
+func TestRequestHeaders(t *testing.T) {
+	// Create a server that checks the headers of every request
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-API-Key") != apikey || r.Header.Get("Accept") != "application/json" {
+			t.Errorf("missing headers on %s %s: %v", r.Method, r.URL.Path, r.Header) // Report requests without the headers
+		}
+		if r.Method == "POST" && r.Header.Get("Content-Type") != "application/json" {
+			t.Errorf("missing content type on %s", r.URL.Path) // Report bodies without a content type
+		}
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	client := NewAPIClient(apikey, WithBaseURL(server.URL)) // Point the client at the test server
+	ctx := context.Background()
+
+	// Call each of the core methods
+	client.PlaceBet(ctx, PlaceBetPayload{})
+	client.AccountBalance(ctx, "PLAY_EUR")
+	client.GetTodayFixtures(ctx, "soccer", 10)
+	client.GetEvent(ctx, "1")
+	client.GetSports(ctx)
+}
+
+// TestPlaceBetTypes tests the JSON shape sent for line and exchange bets
+func TestPlaceBetTypes(t *testing.T) {
+	var sent map[string]any // Variable to hold the last payload received by the server
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		sent = nil
+		json.NewDecoder(r.Body).Decode(&sent)
+		w.Write([]byte(`{"status":"ACCEPTED"}`))
+	}))
+	defer server.Close()
+
+	client := NewAPIClient(apikey, WithBaseURL(server.URL)) // Point the client at the test server
+	ctx := context.Background()
+
+	// Place a line bet, which must not carry a side
+	if _, err := client.PlaceBet(ctx, PlaceBetPayload{MarketURL: "soccer.match_odds/home", Stake: MustMoney("1")}); err != nil {
+		t.Fatalf("expected no error, got %v", err) // Fail the test if an error occurred
+	}
+	if _, ok := sent["side"]; ok || sent["marketUrl"] != "soccer.match_odds/home" || sent["stake"] != "1" {
+		t.Fatalf("unexpected line bet payload %v", sent) // Fail the test if the shape is wrong
+	}
+
+	// Place an exchange bet laying the selection
+	if _, err := client.PlaceBet(ctx, PlaceBetPayload{Type: BetTypeExchange, Side: SideLay}); err != nil {
+		t.Fatalf("expected no error, got %v", err) // Fail the test if an error occurred
+	}
+	if sent["side"] != "LAY" {
+		t.Fatalf("unexpected exchange bet payload %v", sent) // Fail the test if the side was dropped
+	}
+
+	// Exchange bets without a side are rejected before sending
+	if _, err := client.PlaceBet(ctx, PlaceBetPayload{Type: BetTypeExchange}); err == nil {
+		t.Fatal("expected an error for an exchange bet without a side") // Fail the test if it was sent
+	}
+}
+
+// TestRecordedPayloads tests that recorded Cloudbet payloads populate the models and survive a JSON round trip
+func TestRecordedPayloads(t *testing.T) {
+	tests := []struct {
+		file  string // Recorded payload
+		value func() any // New value to decode the payload into
+		check func(t *testing.T, v any) // Assertions on specific decoded fields
+	}{
+		{"cloudbettest/testdata/event.json", func() any { return &Event{} }, func(t *testing.T, v any) {
+			event := v.(*Event)
+			home, err := event.FindSelection("soccer.match_odds", "home", "")
+			if err != nil || home.Price != 1.91 || home.MaxStake != 500 {
+				t.Fatalf("unexpected home selection %+v, %v", home, err) // Fail the test if the markets were dropped
+			}
+			if !event.CutoffTime.Equal(time.Date(2024, 5, 19, 15, 0, 0, 0, time.UTC)) || event.Competition.Category.Key != "england" {
+				t.Fatalf("unexpected event %+v", event) // Fail the test if the event fields were dropped
+			}
+			if len(event.Metadata.Opinion) != 2 || event.Metadata.Opinion[0].Probability != 0.52 || string(event.Metadata.Raw["layout"]) != `"default"` {
+				t.Fatalf("unexpected metadata %+v", event.Metadata) // Fail the test if the metadata was dropped
+			}
+		}},
+		{"cloudbettest/testdata/event_resulted.json", func() any { return &Event{} }, func(t *testing.T, v any) {
+			event := v.(*Event)
+			if event.Status != EventStatusResulted || event.ResultedTime.IsZero() || len(event.Settlement["soccer.asian_handicap"].Submarkets["period=ft"].Selections) != 4 {
+				t.Fatalf("unexpected resulted event %+v", event) // Fail the test if the settlement was dropped
+			}
+		}},
+		{"cloudbettest/testdata/fixtures.json", func() any { return &Fixtures{} }, func(t *testing.T, v any) {
+			events := v.(*Fixtures).Competitions[0].Events
+			if len(events) != 2 || events[1].Status != EventStatusTradingLive || events[1].Home.Key != "c-liverpool" || events[1].CutoffTime.Hour() != 12 {
+				t.Fatalf("unexpected fixtures %+v", events) // Fail the test if the fixture fields were dropped
+			}
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.file, func(t *testing.T) {
+			data, err := os.ReadFile(tt.file)
+			if err != nil {
+				t.Fatal(err)
+			}
+			decoded := tt.value()
+			if err := json.Unmarshal(data, decoded); err != nil {
+				t.Fatalf("expected no error, got %v", err) // Fail the test if the payload no longer decodes
+			}
+			tt.check(t, decoded)
+
+			// Encode and decode again, the tags must agree in both directions
+			encoded, err := json.Marshal(decoded)
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+			again := tt.value()
+			if err := json.Unmarshal(encoded, again); err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+			tt.check(t, again)
+			reencoded, err := json.Marshal(again)
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+			if !bytes.Equal(encoded, reencoded) {
+				t.Fatalf("round trip changed the payload:\n%s\n%s", encoded, reencoded) // Fail the test if a field is lost
+			}
+		})
+	}
+}
@@ -0,0 +1,34 @@
+package cloudbettest_test
+
+import (
+	"context"
+	"testing"
+
+	cloudbet "github.com/Flagon00/CloudbetClient"
+	"github.com/Flagon00/CloudbetClient/cloudbettest"
+)
+
+// TestNewServer tests that the fake API answers the core methods offline
+func TestNewServer(t *testing.T) {
+	server := cloudbettest.NewServer()
+	defer server.Close()
+	client := cloudbet.NewAPIClient("test-api-key", cloudbet.WithBaseURL(server.URL))
+	ctx := context.Background()
+
+	fixtures, err := client.GetTodayFixturesJSON(ctx, "soccer", 10)
+	if err != nil || len(fixtures.Competitions[0].Events) != 2 {
+		t.Fatalf("unexpected fixtures %+v, %v", fixtures, err)
+	}
+	event, err := client.GetEventJSON(ctx, "24055338")
+	if err != nil || event.Name != "Arsenal V Chelsea" {
+		t.Fatalf("unexpected event %+v, %v", event, err)
+	}
+	bet, err := client.PlaceBet(ctx, cloudbet.PlaceBetPayload{UUID: "ref-1", Price: cloudbet.MustMoney("1.91"), Stake: cloudbet.MustMoney("10")})
+	if err != nil || bet.ReferenceID != "ref-1" || bet.ReturnAmount.String() != "19.1" || bet.Status != cloudbet.BetStatusAccepted {
+		t.Fatalf("unexpected bet %+v, %v", bet, err)
+	}
+	balances, err := client.GetAllBalances(ctx)
+	if err != nil || balances["PLAY_EUR"].String() != "1000" {
+		t.Fatalf("unexpected balances %v, %v", balances, err)
+	}
+}
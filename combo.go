@@ -0,0 +1,77 @@
+package cloudbet
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ComboLeg is one selection of a combo bet
+type ComboLeg struct {
+	EventID   string `json:"eventId"`   // ID of the event
+	MarketURL string `json:"marketUrl"` // Market URL of the selection, see BuildMarketURL
+	Price     Money  `json:"price"`     // Price of the selection
+}
+
+// ComboBetPayload defines the payload for placing a combo (accumulator) bet, which wins only if every
+// leg wins
+type ComboBetPayload struct {
+	PriceChange PriceChangePolicy `json:"acceptPriceChange"` // Indicates which price changes are accepted
+	Currency    string            `json:"currency"`          // Currency for the bet, empty for the default currency
+	Legs        []ComboLeg        `json:"selections"`        // Selections of the combo, from different events
+	Price       Money             `json:"price"`             // Combined price, the product of the leg prices when zero
+	UUID        string            `json:"referenceId"`       // Unique reference ID for the bet, generated when empty
+	Stake       Money             `json:"stake"`             // Amount to stake on the combo
+}
+
+// ComboPrice returns the combined price of the legs, the product of their prices
+func ComboPrice(legs []ComboLeg) Money {
+	price := MoneyFromInt(1)
+	for _, leg := range legs {
+		price = price.Mul(leg.Price)
+	}
+	return price
+}
+
+// PlaceCombo submits a combo bet of at least two legs on different events. The response reports
+// the combined price and the potential return. As with PlaceBet, nothing is retried and errors
+// returned once the bet may have been sent carry its reference ID
+func (c *APIClient) PlaceCombo(ctx context.Context, payload ComboBetPayload) (*PlaceBetResponse, error) {
+	start := time.Now()
+	if payload.Currency == "" {
+		payload.Currency = c.defaultCurrency // Explicit currencies always win
+	}
+	if payload.UUID == "" {
+		payload.UUID = uuid.NewString() // A combo is always tracked by its reference ID
+	}
+	if payload.Price.IsZero() {
+		payload.Price = ComboPrice(payload.Legs)
+	}
+
+	bet, err := c.placeCombo(ctx, "PlaceCombo", payload)
+	c.audit(AuditRecord{Combo: &payload, ReferenceID: payload.UUID}, start, bet, err) // Report every attempt, failures included
+	return bet, err
+}
+
+// placeCombo validates and submits a combo bet whose defaults were already applied
+func (c *APIClient) placeCombo(ctx context.Context, method string, payload ComboBetPayload) (*PlaceBetResponse, error) {
+	if err := c.checkCurrency(payload.Currency); err != nil {
+		return nil, err // Return error if real money is not allowed
+	}
+	if len(payload.Legs) < 2 {
+		return nil, fmt.Errorf("combo bets require at least 2 legs, got %d", len(payload.Legs))
+	}
+	events := make(map[string]bool, len(payload.Legs))
+	for i, leg := range payload.Legs {
+		switch {
+		case leg.EventID == "" || leg.MarketURL == "":
+			return nil, fmt.Errorf("combo leg %d requires an event ID and a market URL", i)
+		case events[leg.EventID]:
+			return nil, fmt.Errorf("combo leg %d repeats event %s, legs must be on different events", i, leg.EventID)
+		}
+		events[leg.EventID] = true
+	}
+	return c.submitBet(ctx, method, betsPlaceComboPath, payload.UUID, payload)
+}
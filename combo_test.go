@@ -0,0 +1,55 @@
+package cloudbet
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// TestPlaceCombo tests that a combo is posted with the combined price and that invalid combos are refused
+func TestPlaceCombo(t *testing.T) {
+	var received ComboBetPayload
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/pub/v3/bets/place-combo" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		json.NewDecoder(r.Body).Decode(&received)
+		w.Write([]byte(`{"referenceId":"` + received.UUID + `","price":"3.75","status":"ACCEPTED"}`))
+	}))
+	defer server.Close()
+
+	var record AuditRecord
+	client := NewAPIClient(apikey, WithBaseURL(server.URL), WithDefaultCurrency("PLAY_EUR"), WithAuditSink(func(r AuditRecord) { record = r }))
+	ctx := context.Background()
+
+	legs := []ComboLeg{
+		{EventID: "1", MarketURL: "soccer.match_odds/home", Price: MustMoney("1.5")},
+		{EventID: "2", MarketURL: "soccer.match_odds/away", Price: MustMoney("2.5")},
+	}
+	bet, err := client.PlaceCombo(ctx, ComboBetPayload{Legs: legs, Stake: MustMoney("10")})
+	if err != nil {
+		t.Fatalf("PlaceCombo failed: %v", err)
+	}
+	if received.UUID == "" || received.Currency != "PLAY_EUR" || received.Price.String() != "3.75" || len(received.Legs) != 2 {
+		t.Fatalf("unexpected combo payload %+v", received)
+	}
+	if bet.Status != BetStatusAccepted || bet.ReferenceID != received.UUID {
+		t.Fatalf("unexpected response %+v", bet)
+	}
+	if record.Combo == nil || record.ReferenceID != received.UUID || record.Status != BetStatusAccepted {
+		t.Fatalf("unexpected audit record %+v", record)
+	}
+
+	invalid := [][]ComboLeg{
+		legs[:1], // A single leg is not a combo
+		{legs[0], {EventID: "2", Price: MustMoney("2")}},                // Missing market URL
+		{legs[0], {EventID: "1", MarketURL: "soccer.total_goals/over"}}, // Same event twice
+	}
+	for _, legs := range invalid {
+		if _, err := client.PlaceCombo(ctx, ComboBetPayload{Legs: legs, Stake: MustMoney("10")}); err == nil {
+			t.Fatalf("expected combo %+v to be refused", legs)
+		}
+	}
+}
@@ -0,0 +1,73 @@
+package cloudbet
+
+import (
+	"bufio"
+	"compress/flate"
+	"compress/gzip"
+	"compress/zlib"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// acceptEncoding is sent with every request. Setting it ourselves turns off the transparent gzip
+// support of http.Transport, so responses are decompressed by decompressBody whatever the transport
+const acceptEncoding = "gzip, deflate"
+
+// decompressedBody reads a decompressed response body and closes the original one with it
+type decompressedBody struct {
+	io.Reader
+	closers []io.Closer // Decompressor and original body
+}
+
+// Close implements io.Closer
+func (b *decompressedBody) Close() error {
+	var err error
+	for _, closer := range b.closers {
+		if closeErr := closer.Close(); err == nil {
+			err = closeErr
+		}
+	}
+	return err
+}
+
+// decompressBody replaces the body of a gzip or deflate encoded response with its decompressed content
+func decompressBody(resp *http.Response) error {
+	encoding := strings.ToLower(strings.TrimSpace(resp.Header.Get("Content-Encoding")))
+	if encoding == "" || encoding == "identity" || resp.Uncompressed {
+		return nil // Nothing to do, or the transport already decompressed it
+	}
+
+	var reader io.ReadCloser
+	switch encoding {
+	case "gzip", "x-gzip":
+		gz, err := gzip.NewReader(resp.Body)
+		if err != nil {
+			return fmt.Errorf("invalid gzip response body: %w", err)
+		}
+		reader = gz
+	case "deflate":
+		// Servers disagree on whether deflate means zlib wrapped or raw, look at the zlib header
+		buffered := bufio.NewReader(resp.Body)
+		header, _ := buffered.Peek(2)
+		if len(header) == 2 && header[0]&0x0f == 8 && (uint16(header[0])<<8|uint16(header[1]))%31 == 0 {
+			zr, err := zlib.NewReader(buffered)
+			if err != nil {
+				return fmt.Errorf("invalid deflate response body: %w", err)
+			}
+			reader = zr
+		} else {
+			reader = flate.NewReader(buffered)
+		}
+	default:
+		return nil // Leave unknown encodings to the caller, decoding will report them
+	}
+
+	resp.Body = &decompressedBody{Reader: reader, closers: []io.Closer{reader, resp.Body}}
+	resp.Header.Del("Content-Encoding")
+	resp.Header.Del("Content-Length")
+	resp.ContentLength = -1
+	resp.Uncompressed = true
+	return nil
+}
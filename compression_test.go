@@ -0,0 +1,57 @@
+package cloudbet
+
+import (
+	"bytes"
+	"compress/gzip"
+	"compress/zlib"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+)
+
+// TestCompressedResponses tests that gzip and deflate encoded fixtures are decoded, also when the
+// transport of a custom HTTP client has transparent compression disabled
+func TestCompressedResponses(t *testing.T) {
+	fixtures, err := os.ReadFile("cloudbettest/testdata/fixtures.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var gzipped, deflated bytes.Buffer
+	gz := gzip.NewWriter(&gzipped)
+	gz.Write(fixtures)
+	gz.Close()
+	zw := zlib.NewWriter(&deflated)
+	zw.Write(fixtures)
+	zw.Close()
+
+	for _, encoding := range []string{"gzip", "deflate"} {
+		for _, disableCompression := range []bool{false, true} {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Header.Get("Accept-Encoding") != "gzip, deflate" {
+					t.Errorf("unexpected Accept-Encoding %q", r.Header.Get("Accept-Encoding"))
+				}
+				w.Header().Set("Content-Type", "application/json")
+				w.Header().Set("Content-Encoding", encoding)
+				if encoding == "gzip" {
+					w.Write(gzipped.Bytes())
+				} else {
+					w.Write(deflated.Bytes())
+				}
+			}))
+
+			httpClient := &http.Client{Transport: &http.Transport{DisableCompression: disableCompression}}
+			client := NewAPIClient(apikey, WithBaseURL(server.URL), WithHTTPClient(httpClient))
+
+			result, err := client.GetTodayFixturesJSON(context.Background(), "soccer", 10)
+			server.Close()
+			if err != nil {
+				t.Fatalf("%s (DisableCompression %v): expected no error, got %v", encoding, disableCompression, err)
+			}
+			if len(result.Competitions) == 0 || len(result.Competitions[0].Events) == 0 {
+				t.Fatalf("%s (DisableCompression %v): unexpected fixtures %+v", encoding, disableCompression, result)
+			}
+		}
+	}
+}
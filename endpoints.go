@@ -0,0 +1,73 @@
+package cloudbet
+
+// Default versions of the Cloudbet API resources. Each resource is versioned on its own, which is
+// why balances are v1, odds v2 and bets v3
+const (
+	DefaultAccountAPIVersion = "v1" // Version of the account endpoints
+	DefaultOddsAPIVersion    = "v2" // Version of the odds feed endpoints
+	DefaultTradingAPIVersion = "v3" // Version of the betting endpoints
+)
+
+// Endpoint paths relative to the version prefix of their resource, every URL the client calls is listed here
+const (
+	accountCurrenciesPath   = "/account/currencies"            // Currencies held by the account
+	accountBalancePath      = "/account/currencies/%s/balance" // Balance of one currency
+	accountInfoPath         = "/account/info"                  // Account details
+	accountTransactionsPath = "/account/transactions"          // Ledger of the account balances
+	oddsSportsPath          = "/odds/sports"                   // All sports
+	oddsSportPath           = "/odds/sports/%s"                // One sport with its categories and competitions
+	oddsCompetitionPath     = "/odds/competitions/%s"          // One competition with its events
+	oddsFixturesPath        = "/odds/fixtures"                 // Fixtures of a sport on a day
+	oddsEventsPath          = "/odds/events"                   // Events of a sport
+	oddsEventPath           = "/odds/events/%s"                // One event by ID
+	oddsEventByKeyPath      = "/odds/events/key/%s"            // One event by key
+	oddsStreamPath          = "/odds/stream"                   // Server-Sent Events odds feed
+	betsPlacePath           = "/bets/place"                    // Bet placement
+	betsPlaceComboPath      = "/bets/place-combo"              // Combo bet placement
+	betsStatusPath          = "/bets/%s/status"                // Status of one bet
+	betsHistoryPath         = "/bets/history"                  // Bet history
+)
+
+// WithAccountAPIVersion overrides the version of the account endpoints, e.g. "v2"
+func WithAccountAPIVersion(version string) Option {
+	return func(c *APIClient) {
+		c.accountVersion = version
+	}
+}
+
+// WithOddsAPIVersion overrides the version of the odds feed endpoints, e.g. "v3"
+func WithOddsAPIVersion(version string) Option {
+	return func(c *APIClient) {
+		c.oddsVersion = version
+	}
+}
+
+// WithTradingAPIVersion overrides the version of the betting endpoints, e.g. "v4"
+func WithTradingAPIVersion(version string) Option {
+	return func(c *APIClient) {
+		c.tradingVersion = version
+	}
+}
+
+// versionedPath prefixes path with the version of its resource, falling back to the default version
+func versionedPath(version, fallback, path string) string {
+	if version == "" {
+		version = fallback
+	}
+	return "/pub/" + version + path
+}
+
+// accountPath returns the full path of an account endpoint
+func (c *APIClient) accountPath(path string) string {
+	return versionedPath(c.accountVersion, DefaultAccountAPIVersion, path)
+}
+
+// oddsPath returns the full path of an odds feed endpoint
+func (c *APIClient) oddsPath(path string) string {
+	return versionedPath(c.oddsVersion, DefaultOddsAPIVersion, path)
+}
+
+// tradingPath returns the full path of a betting endpoint
+func (c *APIClient) tradingPath(path string) string {
+	return versionedPath(c.tradingVersion, DefaultTradingAPIVersion, path)
+}
@@ -0,0 +1,27 @@
+package cloudbet
+
+import (
+	"fmt"
+	"os"
+)
+
+// Environment variables read by NewAPIClientFromEnv
+const (
+	EnvAPIKey  = "CLOUDBET_API_KEY"  // API key, required
+	EnvBaseURL = "CLOUDBET_BASE_URL" // Base URL, optional
+)
+
+// NewAPIClientFromEnv initializes a client from the CLOUDBET_API_KEY and optional CLOUDBET_BASE_URL
+// environment variables. The options are applied after them, so they take precedence
+func NewAPIClientFromEnv(opts ...Option) (*APIClient, error) {
+	apiKey := os.Getenv(EnvAPIKey)
+	if apiKey == "" {
+		return nil, fmt.Errorf("%s is not set: %w", EnvAPIKey, ErrMissingAPIKey) // Return error if there is no key to authenticate with
+	}
+
+	var envOpts []Option
+	if baseURL := os.Getenv(EnvBaseURL); baseURL != "" {
+		envOpts = append(envOpts, WithBaseURL(baseURL))
+	}
+	return NewAPIClient(apiKey, append(envOpts, opts...)...), nil
+}
@@ -0,0 +1,29 @@
+package cloudbet
+
+import (
+	"errors"
+	"testing"
+)
+
+// TestNewAPIClientFromEnv tests that the environment configures the client below explicit options
+func TestNewAPIClientFromEnv(t *testing.T) {
+	t.Setenv(EnvAPIKey, "")
+	if _, err := NewAPIClientFromEnv(); !errors.Is(err, ErrMissingAPIKey) {
+		t.Fatalf("expected ErrMissingAPIKey, got %v", err)
+	}
+
+	t.Setenv(EnvAPIKey, apikey)
+	t.Setenv(EnvBaseURL, "https://staging.example.com")
+	client, err := NewAPIClientFromEnv()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if client.APIKey != apikey || client.BaseURL != "https://staging.example.com" {
+		t.Fatalf("unexpected client %v", client)
+	}
+
+	client, err = NewAPIClientFromEnv(WithBaseURL("https://other.example.com"))
+	if err != nil || client.BaseURL != "https://other.example.com" {
+		t.Fatalf("expected the option to override the environment, got %v, %v", client, err)
+	}
+}
@@ -0,0 +1,193 @@
+package cloudbet
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+var (
+	// ErrAuthentication is matched by APIErrors caused by a missing or invalid API key
+	ErrAuthentication = errors.New("cloudbet: authentication failed")
+	// ErrEventNotResulted is returned when results are requested for an event that has not been resulted
+	ErrEventNotResulted = errors.New("cloudbet: event not resulted")
+	// ErrSelectionNotFound is returned when a market or selection does not exist on an event
+	ErrSelectionNotFound = errors.New("cloudbet: selection not found")
+	// ErrMissingAPIKey is returned by NewAPIClientFromEnv when no API key is configured
+	ErrMissingAPIKey = errors.New("cloudbet: missing API key")
+	// ErrClosed is returned by calls made after Close
+	ErrClosed = errors.New("cloudbet: client closed")
+	// ErrRealMoney is returned when a bet in a real currency is placed by a client created with WithPlayMoneyOnly
+	ErrRealMoney = errors.New("cloudbet: real money bets are disabled")
+	// ErrResponseTooLarge is returned when a response body exceeds the WithMaxResponseBytes limit
+	ErrResponseTooLarge = errors.New("cloudbet: response too large")
+	// ErrInvalidSignature is returned by ParseWebhook when the signature of a webhook is missing or wrong
+	ErrInvalidSignature = errors.New("cloudbet: invalid webhook signature")
+	// ErrUnknownBetStatus is returned by WaitForSettlement when a bet reports a status this library does not know
+	ErrUnknownBetStatus = errors.New("cloudbet: unknown bet status")
+
+	// Bet rejections, matched by APIErrors carrying one of the corresponding Cloudbet codes
+	ErrInsufficientFunds = errors.New("cloudbet: insufficient funds")
+	ErrMarketSuspended   = errors.New("cloudbet: market suspended")
+	ErrPriceChanged      = errors.New("cloudbet: price changed")
+	ErrStakeTooLow       = errors.New("cloudbet: stake too low")
+)
+
+// codeErrors maps Cloudbet rejection codes to the sentinel error they match
+var codeErrors = map[string]error{
+	"INSUFFICIENT_FUNDS":  ErrInsufficientFunds,
+	"MARKET_SUSPENDED":    ErrMarketSuspended,
+	"SELECTION_SUSPENDED": ErrMarketSuspended,
+	"MARKET_CLOSED":       ErrMarketSuspended,
+	"PRICE_ABOVE_MARKET":  ErrPriceChanged,
+	"PRICE_CHANGED":       ErrPriceChanged,
+	"STAKE_TOO_LOW":       ErrStakeTooLow,
+	"STAKE_BELOW_MIN_BET": ErrStakeTooLow,
+}
+
+// isRejectionCode reports whether code is a Cloudbet rejection code matched by a sentinel error
+func isRejectionCode(code string) bool {
+	_, ok := codeErrors[strings.ToUpper(code)]
+	return ok
+}
+
+// APIError is returned when the Cloudbet API responds with a non successful status code
+type APIError struct {
+	StatusCode int    // HTTP status code of the response
+	Code       string // Cloudbet error code, e.g. INSUFFICIENT_FUNDS
+	Message    string // Error message parsed from the response body
+}
+
+// Error implements the error interface
+func (e *APIError) Error() string {
+	msg := fmt.Sprintf("cloudbet: %d %s", e.StatusCode, http.StatusText(e.StatusCode))
+	if e.Code != "" {
+		msg += ": " + e.Code // Append the Cloudbet error code when present
+	}
+	if e.Message != "" && e.Message != e.Code {
+		msg += ": " + e.Message // Append the message when it adds information
+	}
+	return msg
+}
+
+// Is reports whether the error matches target, allowing errors.Is(err, ErrAuthentication)
+// and errors.Is(err, ErrInsufficientFunds) and the other rejection sentinels
+func (e *APIError) Is(target error) bool {
+	if target == ErrAuthentication {
+		return e.StatusCode == http.StatusUnauthorized || e.StatusCode == http.StatusForbidden
+	}
+	sentinel, ok := codeErrors[strings.ToUpper(e.Code)]
+	return ok && sentinel == target
+}
+
+// apiErrorBody defines the error shapes Cloudbet returns in response bodies
+type apiErrorBody struct {
+	Code    string `json:"code"`    // Machine readable error code
+	Status  string `json:"status"`  // Status of the request, used as code when no code is set
+	Error   string `json:"error"`   // Error message
+	Message string `json:"message"` // Alternative error message field
+}
+
+// newAPIError builds an APIError from a response and its already read body
+func newAPIError(resp *http.Response, body []byte) *APIError {
+	apiErr := &APIError{StatusCode: resp.StatusCode}
+
+	var parsed apiErrorBody
+	if err := json.Unmarshal(body, &parsed); err != nil {
+		apiErr.Message = strings.TrimSpace(string(body)) // Keep the raw body when it is not JSON
+		return apiErr
+	}
+
+	apiErr.Code = parsed.Code
+	if apiErr.Code == "" {
+		apiErr.Code = parsed.Status // Bet rejections carry the reason in the status field
+	}
+	apiErr.Message = parsed.Error
+	if apiErr.Message == "" {
+		apiErr.Message = parsed.Message
+	}
+	return apiErr
+}
+
+// BetSubmissionError is returned by PlaceBet once a bet may have been sent, it carries the reference
+// ID that was submitted so the outcome can be checked with GetBetStatus, e.g. after a timeout
+type BetSubmissionError struct {
+	ReferenceID string // Reference ID sent with the bet
+	Err         error  // Underlying error
+}
+
+// newBetSubmissionError wraps err with the reference ID of the submitted bet
+func newBetSubmissionError(referenceID string, err error) *BetSubmissionError {
+	return &BetSubmissionError{ReferenceID: referenceID, Err: err}
+}
+
+// Error implements the error interface
+func (e *BetSubmissionError) Error() string {
+	if e.ReferenceID == "" {
+		return e.Err.Error()
+	}
+	return fmt.Sprintf("%v (reference ID %s)", e.Err, e.ReferenceID)
+}
+
+// Unwrap returns the underlying error
+func (e *BetSubmissionError) Unwrap() error {
+	return e.Err
+}
+
+// MismatchError is returned by PlaceBet when the reference ID echoed by Cloudbet is not the one sent,
+// e.g. because a proxy mangled the request. The response cannot be trusted to describe the bet
+type MismatchError struct {
+	Sent     string // Reference ID sent with the bet
+	Received string // Reference ID echoed in the response
+}
+
+// Error implements the error interface
+func (e *MismatchError) Error() string {
+	return fmt.Sprintf("cloudbet: reference ID mismatch: sent %s, received %s", e.Sent, e.Received)
+}
+
+// NetworkError is returned when a request could not be sent or its response not received, e.g. on a
+// DNS failure or a reset connection. These failures are usually worth retrying
+type NetworkError struct {
+	Err error // Underlying transport error
+}
+
+// Error implements the error interface
+func (e *NetworkError) Error() string {
+	return "cloudbet: network error: " + e.Err.Error()
+}
+
+// Unwrap returns the underlying transport error
+func (e *NetworkError) Unwrap() error {
+	return e.Err
+}
+
+// DecodeError is returned when a successful response could not be decoded, e.g. an empty body or an
+// HTML error page. Retrying does not help
+type DecodeError struct {
+	StatusCode  int    // HTTP status code of the response
+	ContentType string // Content-Type of the response
+	Body        string // Start of the response body, empty when the body was empty or unreadable
+	Err         error  // Underlying JSON or decompression error, nil when the body was not JSON at all
+}
+
+// Error implements the error interface
+func (e *DecodeError) Error() string {
+	switch {
+	case e.Body == "" && e.Err == nil:
+		return fmt.Sprintf("empty response body (status %d)", e.StatusCode)
+	case e.Body == "":
+		return fmt.Sprintf("unreadable response body (status %d): %v", e.StatusCode, e.Err)
+	case e.Err == nil:
+		return fmt.Sprintf("expected JSON, got %q (status %d): %s", e.ContentType, e.StatusCode, e.Body)
+	default:
+		return fmt.Sprintf("invalid JSON response (status %d): %v: %s", e.StatusCode, e.Err, e.Body)
+	}
+}
+
+// Unwrap returns the underlying JSON error
+func (e *DecodeError) Unwrap() error {
+	return e.Err
+}
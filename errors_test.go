@@ -0,0 +1,201 @@
+package cloudbet
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// TestPlaceBetAPIError tests that a rejected bet is returned as an APIError
+func TestPlaceBetAPIError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"status":"INSUFFICIENT_FUNDS","error":"not enough funds"}`))
+	}))
+	defer server.Close()
+
+	client := NewAPIClient(apikey, WithBaseURL(server.URL))
+
+	bet, err := client.PlaceBet(context.Background(), PlaceBetPayload{Currency: "PLAY_EUR"})
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("expected APIError, got %v", err)
+	}
+	if apiErr.StatusCode != http.StatusBadRequest || apiErr.Code != "INSUFFICIENT_FUNDS" || apiErr.Message != "not enough funds" {
+		t.Fatalf("unexpected APIError %+v", apiErr)
+	}
+	if bet == nil || bet.Error != "not enough funds" {
+		t.Fatalf("expected bet Error to be populated, got %+v", bet)
+	}
+}
+
+// TestNewAPIErrorNonJSON tests that a non JSON body is kept as the message
+func TestNewAPIErrorNonJSON(t *testing.T) {
+	resp := &http.Response{StatusCode: http.StatusBadGateway}
+
+	apiErr := newAPIError(resp, []byte("<html>bad gateway</html>\n"))
+	if apiErr.Message != "<html>bad gateway</html>" || apiErr.Code != "" {
+		t.Fatalf("unexpected APIError %+v", apiErr)
+	}
+}
+
+// TestStatusCodeChecked tests that every method reports a non successful status as an APIError
+func TestStatusCodeChecked(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+		w.Write([]byte(`<html>unauthorized</html>`))
+	}))
+	defer server.Close()
+
+	client := NewAPIClient("wrong-key", WithBaseURL(server.URL))
+	ctx := context.Background()
+
+	_, balanceErr := client.AccountBalance(ctx, "PLAY_EUR")
+	_, eventErr := client.GetEventJSON(ctx, "1")
+	_, fixturesErr := client.GetTodayFixturesJSON(ctx, "soccer", 10)
+	for _, err := range []error{balanceErr, eventErr, fixturesErr} {
+		var apiErr *APIError
+		if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusUnauthorized {
+			t.Fatalf("expected a 401 APIError, got %v", err)
+		}
+		if !errors.Is(err, ErrAuthentication) {
+			t.Fatalf("expected an authentication error, got %v", err)
+		}
+	}
+}
+
+// TestRejectionSentinels tests that Cloudbet codes map to the sentinel errors
+func TestRejectionSentinels(t *testing.T) {
+	tests := map[string]error{
+		"INSUFFICIENT_FUNDS":  ErrInsufficientFunds,
+		"MARKET_SUSPENDED":    ErrMarketSuspended,
+		"PRICE_ABOVE_MARKET":  ErrPriceChanged,
+		"STAKE_BELOW_MIN_BET": ErrStakeTooLow,
+	}
+	for code, sentinel := range tests {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"status":"` + code + `","error":"rejected: ` + code + `"}`))
+		}))
+		client := NewAPIClient(apikey, WithBaseURL(server.URL))
+
+		_, err := client.PlaceBet(context.Background(), PlaceBetPayload{})
+		server.Close()
+		if !errors.Is(err, sentinel) {
+			t.Fatalf("%s: expected %v, got %v", code, sentinel, err)
+		}
+		if errors.Is(err, ErrAuthentication) {
+			t.Fatalf("%s: unexpected authentication error", code)
+		}
+		var apiErr *APIError
+		if !errors.As(err, &apiErr) || apiErr.Message != "rejected: "+code {
+			t.Fatalf("%s: expected the original message, got %v", code, err)
+		}
+	}
+}
+
+// TestRejectionSentinelsOK tests that rejections returned with a 200 status map to the sentinel errors too
+func TestRejectionSentinelsOK(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"referenceId":"ref-1","status":"INSUFFICIENT_FUNDS","error":"no funds"}`))
+	}))
+	defer server.Close()
+	client := NewAPIClient(apikey, WithBaseURL(server.URL))
+
+	bet, err := client.PlaceBet(context.Background(), PlaceBetPayload{UUID: "ref-1"})
+	if !errors.Is(err, ErrInsufficientFunds) {
+		t.Fatalf("expected ErrInsufficientFunds, got %v", err)
+	}
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusOK || apiErr.Code != "INSUFFICIENT_FUNDS" || apiErr.Message != "no funds" {
+		t.Fatalf("expected the rejection as an APIError, got %v", err)
+	}
+	if bet == nil || bet.Status != BetStatusInsufficientFunds || bet.ReferenceID != "ref-1" {
+		t.Fatalf("expected the response to be returned, got %+v", bet)
+	}
+}
+
+// TestBetSubmissionError tests that a timed out bet still reports the reference ID it was sent with
+func TestBetSubmissionError(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release // Do not answer before the client gave up, as if the bet got stuck
+	}))
+	defer server.Close()
+	defer close(release)
+
+	client := NewAPIClient(apikey, WithBaseURL(server.URL), WithAutoReferenceID())
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	bet, err := client.PlaceBet(ctx, PlaceBetPayload{Currency: "PLAY_EUR"})
+	var submitErr *BetSubmissionError
+	if !errors.As(err, &submitErr) || !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected a BetSubmissionError wrapping the deadline, got %v", err)
+	}
+	if _, parseErr := uuid.Parse(submitErr.ReferenceID); parseErr != nil {
+		t.Fatalf("expected a generated UUID reference ID, got %q", submitErr.ReferenceID)
+	}
+	if bet == nil || bet.ReferenceID != submitErr.ReferenceID {
+		t.Fatalf("expected the reference ID on the response, got %+v", bet)
+	}
+}
+
+// TestMismatchError tests that a response about another reference ID is reported
+func TestMismatchError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"referenceId":"someone-else","status":"ACCEPTED"}`))
+	}))
+	defer server.Close()
+
+	client := NewAPIClient(apikey, WithBaseURL(server.URL))
+
+	bet, err := client.PlaceBet(context.Background(), PlaceBetPayload{Currency: "PLAY_EUR", UUID: "ref-1"})
+	var mismatch *MismatchError
+	var submitErr *BetSubmissionError
+	if !errors.As(err, &mismatch) || mismatch.Sent != "ref-1" || mismatch.Received != "someone-else" || !errors.As(err, &submitErr) {
+		t.Fatalf("expected a MismatchError, got %v", err)
+	}
+	if bet == nil || bet.ReferenceID != "someone-else" {
+		t.Fatalf("expected the mismatched response, got %+v", bet)
+	}
+}
+
+// TestErrorKinds tests that network, decode and API failures can be told apart
+func TestErrorKinds(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/pub/v2/odds/events/html":
+			w.Header().Set("Content-Type", "text/html")
+			w.Write([]byte("<html>maintenance</html>"))
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"error":"bad event id"}`))
+		}
+	}))
+	client := NewAPIClient(apikey, WithBaseURL(server.URL))
+	ctx := context.Background()
+
+	var decodeErr *DecodeError
+	if _, err := client.GetEventJSON(ctx, "html"); !errors.As(err, &decodeErr) || decodeErr.ContentType != "text/html" {
+		t.Fatalf("expected a DecodeError, got %v", err)
+	}
+	var apiErr *APIError
+	if _, err := client.GetEventJSON(ctx, "bad"); !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusBadRequest {
+		t.Fatalf("expected an APIError, got %v", err)
+	}
+
+	server.Close()
+	var networkErr *NetworkError
+	if _, err := client.GetEventJSON(ctx, "1"); !errors.As(err, &networkErr) || errors.As(err, &decodeErr) {
+		t.Fatalf("expected a NetworkError, got %v", err)
+	}
+	if _, err := client.PlaceBet(ctx, PlaceBetPayload{UUID: "ref-1"}); !errors.As(err, &networkErr) {
+		t.Fatalf("expected a NetworkError from PlaceBet, got %v", err)
+	}
+}
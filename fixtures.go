@@ -0,0 +1,318 @@
+package cloudbet
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// FixturesPage defines one page of fixtures and the cursor of the next page
+type FixturesPage struct {
+	Fixtures   *Fixtures // Fixtures of this page
+	NextCursor string    // Cursor of the next page, empty on the last page
+}
+
+// fixturesPageResponse defines the structure for a paginated fixtures response
+type fixturesPageResponse struct {
+	Fixtures
+	Cursor string `json:"cursor"` // Cursor of the next page
+}
+
+// GetFixturesPage retrieves one page of fixtures for a sport on the given day. Pass an empty
+// cursor for the first page and the returned NextCursor for the following ones
+func (c *APIClient) GetFixturesPage(ctx context.Context, sport SportKey, date time.Time, limit int, cursor string) (*FixturesPage, error) {
+	query := url.Values{}
+	query.Set("sport", string(sport))
+	query.Set("date", date.Format("2006-01-02"))
+	query.Set("players", "false")
+	query.Set("limit", strconv.Itoa(limit))
+	if cursor != "" {
+		query.Set("cursor", cursor) // Continue after the previous page
+	}
+
+	var page fixturesPageResponse // Variable to hold the fixtures page response
+	if err := c.getJSON(ctx, "GetFixturesPage", "failed to get fixtures", c.oddsPath(oddsFixturesPath)+"?"+query.Encode(), &page); err != nil {
+		return nil, err // Return error if the request fails
+	}
+
+	return &FixturesPage{Fixtures: &page.Fixtures, NextCursor: page.Cursor}, nil
+}
+
+// GetLiveFixtures retrieves the in-play events of a sport. Pre-match and finished events are excluded
+func (c *APIClient) GetLiveFixtures(ctx context.Context, sport SportKey) (*Fixtures, error) {
+	query := url.Values{}
+	query.Set("sport", string(sport))
+	query.Set("live", "true") // Ask the events endpoint for in-play events only
+	query.Set("players", "false")
+
+	var fixtures Fixtures // Variable to hold the live events response
+	if err := c.getJSON(ctx, "GetLiveFixtures", "failed to get live fixtures", c.oddsPath(oddsEventsPath)+"?"+query.Encode(), &fixtures); err != nil {
+		return nil, err // Return error if the request fails
+	}
+
+	// Filter on the status too, so events that finished since being listed are dropped
+	return filterEvents(&fixtures, func(event Events) bool {
+		return event.Status == EventStatusTradingLive
+	}), nil
+}
+
+// GetEventsByStatus retrieves the events of a sport currently listed by Cloudbet with the given status,
+// e.g. EventStatusTrading, across all competitions. Events resulted some time ago are no longer listed
+// and cannot be found this way
+func (c *APIClient) GetEventsByStatus(ctx context.Context, sport SportKey, status EventStatus) ([]Events, error) {
+	query := url.Values{}
+	query.Set("sport", string(sport))
+	query.Set("players", "false")
+
+	var fixtures Fixtures // Variable to hold the events response
+	if err := c.getJSON(ctx, "GetEventsByStatus", "failed to get events", c.oddsPath(oddsEventsPath)+"?"+query.Encode(), &fixtures); err != nil {
+		return nil, err // Return error if the request fails
+	}
+
+	var events []Events
+	for _, competition := range filterEvents(&fixtures, func(event Events) bool { return event.Status == status }).Competitions {
+		events = append(events, competition.Events...)
+	}
+	return events, nil
+}
+
+// filterEvents returns the fixtures with only the events matching keep, dropping empty competitions
+func filterEvents(fixtures *Fixtures, keep func(Events) bool) *Fixtures {
+	filtered := &Fixtures{}
+	for _, competition := range fixtures.Competitions {
+		var events []Events
+		for _, event := range competition.Events {
+			if keep(event) {
+				events = append(events, event)
+			}
+		}
+		if len(events) > 0 {
+			competition.Events = events
+			filtered.Competitions = append(filtered.Competitions, competition)
+		}
+	}
+	return filtered
+}
+
+// GetMultiSportFixtures retrieves the fixtures of several sports on the given day concurrently,
+// keyed by sport. Failures are reported per sport in the returned error map
+func (c *APIClient) GetMultiSportFixtures(ctx context.Context, sports []SportKey, date time.Time, limit int) (map[SportKey]*Fixtures, map[SportKey]error) {
+	return fanOut(ctx, c.batchConcurrency, sports, func(ctx context.Context, sport SportKey) (*Fixtures, error) {
+		return c.GetFixturesByDateJSON(ctx, sport, date, limit)
+	})
+}
+
+// GetTodayFixturesWithOdds retrieves today's events of a sport with their markets parsed inline, so a
+// board can be built without a GetEvent per fixture. Pass market keys (e.g. "soccer.match_odds") to
+// include only those markets and keep the payload small, all markets are included when none are given
+func (c *APIClient) GetTodayFixturesWithOdds(ctx context.Context, sport SportKey, limit int, marketKeys ...string) (*Fixtures, error) {
+	now := time.Now()
+	from := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+
+	query := url.Values{}
+	query.Set("sport", string(sport))
+	query.Set("from", strconv.FormatInt(from.Unix(), 10))
+	query.Set("to", strconv.FormatInt(from.AddDate(0, 0, 1).Unix(), 10))
+	query.Set("players", "false")
+	query.Set("limit", strconv.Itoa(limit))
+	for _, marketKey := range marketKeys {
+		query.Add("markets", marketKey) // Only send the requested markets
+	}
+
+	var fixtures Fixtures // Variable to hold the events response
+	if err := c.getJSON(ctx, "GetTodayFixturesWithOdds", "failed to get fixtures with odds", c.oddsPath(oddsEventsPath)+"?"+query.Encode(), &fixtures); err != nil {
+		return nil, err // Return error if the request fails
+	}
+	return &fixtures, nil
+}
+
+// GetUpcomingFixtures retrieves the events of a sport whose cutoff time falls within the given duration
+// from now, e.g. the next two hours, even when the window spans midnight. Events past their cutoff are excluded
+func (c *APIClient) GetUpcomingFixtures(ctx context.Context, sport SportKey, within time.Duration) (*Fixtures, error) {
+	now := time.Now()
+	until := now.Add(within)
+
+	query := url.Values{}
+	query.Set("sport", string(sport))
+	query.Set("from", strconv.FormatInt(now.Unix(), 10))
+	query.Set("to", strconv.FormatInt(until.Unix()+1, 10)) // The window is in whole seconds, round it up
+	query.Set("players", "false")
+
+	var fixtures Fixtures // Variable to hold the events response
+	if err := c.getJSON(ctx, "GetUpcomingFixtures", "failed to get upcoming fixtures", c.oddsPath(oddsEventsPath)+"?"+query.Encode(), &fixtures); err != nil {
+		return nil, err // Return error if the request fails
+	}
+
+	// Filter on the cutoff time too, the window of the endpoint is not guaranteed to match it
+	return filterEvents(&fixtures, func(event Events) bool {
+		return event.CutoffTime.After(now) && !event.CutoffTime.After(until)
+	}), nil
+}
+
+// fixturesIteratorPageSize is the number of fixtures fetched per page by EventIterator
+const fixturesIteratorPageSize = 100
+
+// EventIterator walks the fixtures of a sport on one day event by event, fetching the pages lazily
+// so only one page is held in memory. Call Next until it returns false, then check Err
+type EventIterator struct {
+	client *APIClient
+	ctx    context.Context
+	sport  SportKey
+	date   time.Time
+
+	cursor      string         // Cursor of the next page
+	fetched     bool           // Whether the first page was fetched
+	competition Competitions   // Competition of the current event, without its events
+	pending     []Competitions // Competitions of the current page not walked yet
+	events      []Events       // Events of the current competition not returned yet
+	err         error          // Error that stopped the iteration
+}
+
+// FixturesIterator returns an iterator over the fixtures of a sport on the given day. Requests are
+// bound to ctx and made as Next is called
+func (c *APIClient) FixturesIterator(ctx context.Context, sport SportKey, date time.Time) *EventIterator {
+	return &EventIterator{client: c, ctx: ctx, sport: sport, date: date}
+}
+
+// Next returns the next event, fetching the next page when the current one is exhausted. It returns
+// false once every event was returned, a page failed or the server repeated the cursor it was sent,
+// see Err
+func (it *EventIterator) Next() (*Events, bool) {
+	for len(it.events) == 0 {
+		if len(it.pending) > 0 {
+			it.competition, it.pending = it.pending[0], it.pending[1:]
+			it.events, it.competition.Events = it.competition.Events, nil
+			continue
+		}
+		if it.err != nil || (it.fetched && it.cursor == "") {
+			return nil, false // Stop after the last page or an error
+		}
+
+		page, err := it.client.GetFixturesPage(it.ctx, it.sport, it.date, fixturesIteratorPageSize, it.cursor)
+		if err != nil {
+			it.err = err
+			return nil, false
+		}
+		if page.NextCursor != "" && page.NextCursor == it.cursor {
+			it.err = fmt.Errorf("failed to get fixtures page: cursor %q repeated by the server", it.cursor) // Stop instead of requesting the same page forever
+		}
+		it.fetched, it.cursor, it.pending = true, page.NextCursor, page.Fixtures.Competitions
+	}
+
+	event := it.events[0]
+	it.events = it.events[1:]
+	return &event, true
+}
+
+// Competition returns the competition of the event last returned by Next, without its events
+func (it *EventIterator) Competition() Competitions {
+	return it.competition
+}
+
+// Err returns the error that stopped the iteration, nil when every event was returned
+func (it *EventIterator) Err() error {
+	return it.err
+}
+
+// eventsResponse defines the structure for the events response, decoding the events in full
+type eventsResponse struct {
+	Competitions []struct {
+		Events []Event `json:"events"` // Events of the competition, with their markets
+	} `json:"competitions"`
+}
+
+// GetEventsSince retrieves the events of a sport that changed after the given sequence number, with
+// their markets, and the highest sequence number seen to pass on the next call (or sequence itself when
+// nothing changed). Pass 0 for the first sync. Cloudbet has no sequence filter, so the events are filtered
+// once received: this saves processing, not bandwidth. Events that ended since the last call are not listed
+func (c *APIClient) GetEventsSince(ctx context.Context, sport SportKey, sequence int) ([]Event, int, error) {
+	query := url.Values{}
+	query.Set("sport", string(sport))
+	query.Set("players", "false")
+
+	var response eventsResponse // Variable to hold the events response
+	if err := c.getJSON(ctx, "GetEventsSince", "failed to get events", c.oddsPath(oddsEventsPath)+"?"+query.Encode(), &response); err != nil {
+		return nil, sequence, err // Return error if the request fails
+	}
+
+	var changed []Event
+	latest := sequence
+	for _, competition := range response.Competitions {
+		for _, event := range competition.Events {
+			if event.Sequence > sequence {
+				changed = append(changed, event)
+				latest = max(latest, event.Sequence)
+			}
+		}
+	}
+	return changed, latest, nil
+}
+
+// GetFixturesRange retrieves the fixtures of a sport for every day from from to to, both included, e.g.
+// a week-ahead schedule. Days are fetched concurrently and merged in order, competitions and events
+// listed on several days appear once. At most limit events are returned in total, truncated reports
+// whether some were left out. limit must be positive. A day that fails fails the whole range
+func (c *APIClient) GetFixturesRange(ctx context.Context, sport SportKey, from, to time.Time, limit int) (fixtures *Fixtures, truncated bool, err error) {
+	if limit <= 0 {
+		return nil, false, fmt.Errorf("invalid fixtures limit %d, it must be positive", limit)
+	}
+
+	var days []string
+	for day := from; !day.After(to) || sameDay(day, to); day = day.AddDate(0, 0, 1) {
+		days = append(days, day.Format("2006-01-02"))
+	}
+
+	pages, errs := fanOut(ctx, c.batchConcurrency, days, func(ctx context.Context, day string) (*Fixtures, error) {
+		date, _ := time.Parse("2006-01-02", day)
+		return c.GetFixturesByDateJSON(ctx, sport, date, limit+1) // One more than the total tells a full day from a cut one
+	})
+	for _, day := range days {
+		if err := errs[day]; err != nil {
+			return nil, false, fmt.Errorf("failed to get fixtures for %s: %w", day, err)
+		}
+	}
+
+	merged := make(map[CompetitionKey]*Competitions) // Competitions by key, in order of first appearance
+	var order []CompetitionKey
+	seen := make(map[int]bool) // Events already merged
+	for _, day := range days {
+		for _, competition := range pages[day].Competitions {
+			for _, event := range competition.Events {
+				if seen[event.ID] {
+					continue // Listed on an earlier day too
+				}
+				if len(seen) >= limit {
+					truncated = true
+					break
+				}
+				seen[event.ID] = true
+				if merged[competition.Key] == nil {
+					merged[competition.Key] = &Competitions{Name: competition.Name, Key: competition.Key, Sport: competition.Sport, Category: competition.Category}
+					order = append(order, competition.Key)
+				}
+				merged[competition.Key].Events = append(merged[competition.Key].Events, event)
+			}
+		}
+	}
+
+	fixtures = &Fixtures{}
+	for _, key := range order {
+		fixtures.Competitions = append(fixtures.Competitions, *merged[key])
+	}
+	return fixtures, truncated, nil
+}
+
+// sameDay reports whether two times fall on the same calendar day
+func sameDay(a, b time.Time) bool {
+	ay, am, ad := a.Date()
+	by, bm, bd := b.Date()
+	return ay == by && am == bm && ad == bd
+}
+
+// Detailed fetches the full Event of a fixture entry, with its competition, sport, markets and
+// settlement. Market keys filter the markets, as for GetEventJSON
+func (e Events) Detailed(ctx context.Context, client *APIClient, marketKeys ...string) (*Event, error) {
+	return client.GetEventJSON(ctx, strconv.Itoa(e.ID), marketKeys...)
+}
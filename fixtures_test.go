@@ -0,0 +1,337 @@
+package cloudbet
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// TestGetFixturesPage tests that the cursor is sent and the next cursor returned
+func TestGetFixturesPage(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Query().Get("cursor") {
+		case "":
+			w.Write([]byte(`{"competitions":[{"key":"a","events":[{"id":1}]}],"cursor":"page-2"}`))
+		case "page-2":
+			w.Write([]byte(`{"competitions":[{"key":"a","events":[{"id":2}]}]}`))
+		default:
+			t.Errorf("unexpected cursor %s", r.URL.Query().Get("cursor"))
+		}
+	}))
+	defer server.Close()
+
+	client := NewAPIClient(apikey, WithBaseURL(server.URL))
+	date := time.Date(2024, 5, 19, 0, 0, 0, 0, time.UTC)
+
+	page, err := client.GetFixturesPage(context.Background(), "soccer", date, 1, "")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if page.NextCursor != "page-2" {
+		t.Fatalf("expected next cursor page-2, got %q", page.NextCursor)
+	}
+
+	page, err = client.GetFixturesPage(context.Background(), "soccer", date, 1, page.NextCursor)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if page.NextCursor != "" || page.Fixtures.Competitions[0].Events[0].ID != 2 {
+		t.Fatalf("unexpected last page %+v", page)
+	}
+}
+
+// TestGetLiveFixtures tests that only in-play events are returned
+func TestGetLiveFixtures(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/pub/v2/odds/events" || r.URL.Query().Get("live") != "true" {
+			t.Errorf("unexpected request %s", r.URL)
+		}
+		w.Write([]byte(`{"competitions":[
+			{"key":"a","events":[{"id":1,"status":"TRADING_LIVE"},{"id":2,"status":"TRADING"}]},
+			{"key":"b","events":[{"id":3,"status":"RESULTED"}]}]}`))
+	}))
+	defer server.Close()
+
+	client := NewAPIClient(apikey, WithBaseURL(server.URL))
+
+	fixtures, err := client.GetLiveFixtures(context.Background(), "soccer")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(fixtures.Competitions) != 1 || len(fixtures.Competitions[0].Events) != 1 || fixtures.Competitions[0].Events[0].ID != 1 {
+		t.Fatalf("unexpected fixtures %+v", fixtures)
+	}
+}
+
+// TestGetEventsByStatus tests that events of every competition are filtered by status
+func TestGetEventsByStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/pub/v2/odds/events" || r.URL.Query().Get("sport") != "soccer" {
+			t.Errorf("unexpected request %s", r.URL)
+		}
+		w.Write([]byte(`{"competitions":[
+			{"key":"a","events":[{"id":1,"status":"TRADING"},{"id":2,"status":"RESULTED"}]},
+			{"key":"b","events":[{"id":3,"status":"TRADING"},{"id":4,"status":"SOMETHING_NEW"}]}]}`))
+	}))
+	defer server.Close()
+
+	client := NewAPIClient(apikey, WithBaseURL(server.URL))
+	ctx := context.Background()
+
+	events, err := client.GetEventsByStatus(ctx, "soccer", EventStatusTrading)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(events) != 2 || events[0].ID != 1 || events[1].ID != 3 {
+		t.Fatalf("unexpected events %+v", events)
+	}
+	if events, err := client.GetEventsByStatus(ctx, "soccer", EventStatusCancelled); err != nil || len(events) != 0 {
+		t.Fatalf("expected no cancelled events, got %+v, %v", events, err)
+	}
+}
+
+// TestGetMultiSportFixtures tests that sports are fetched independently
+func TestGetMultiSportFixtures(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		sport := r.URL.Query().Get("sport")
+		if sport == "cricket" {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.Write([]byte(`{"competitions":[{"key":"` + sport + `-league"}]}`))
+	}))
+	defer server.Close()
+
+	client := NewAPIClient(apikey, WithBaseURL(server.URL))
+
+	fixtures, errs := client.GetMultiSportFixtures(context.Background(), []SportKey{"soccer", "tennis", "cricket"}, time.Now(), 10)
+	if len(fixtures) != 2 || fixtures["tennis"].Competitions[0].Key != "tennis-league" {
+		t.Fatalf("unexpected fixtures %v", fixtures)
+	}
+	if len(errs) != 1 || errs["cricket"] == nil {
+		t.Fatalf("expected one error for cricket, got %v", errs)
+	}
+}
+
+// TestGetTodayFixturesWithOdds tests that the requested markets are sent and parsed inline
+func TestGetTodayFixturesWithOdds(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		if r.URL.Path != "/pub/v2/odds/events" || query.Get("from") == "" || query.Get("to") == "" ||
+			len(query["markets"]) != 2 || query["markets"][0] != "soccer.match_odds" {
+			t.Errorf("unexpected request %s", r.URL)
+		}
+		w.Write([]byte(`{"competitions":[{"key":"a","events":[{"id":1,"status":"TRADING","markets":{
+			"soccer.match_odds":{"submarkets":{"period=ft":{"sequence":4,"selections":[{"outcome":"home","price":1.91,"status":"SELECTION_ENABLED"}]}}}}}]}]}`))
+	}))
+	defer server.Close()
+
+	client := NewAPIClient(apikey, WithBaseURL(server.URL))
+
+	fixtures, err := client.GetTodayFixturesWithOdds(context.Background(), "soccer", 10, "soccer.match_odds", "soccer.total_goals")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	selections := fixtures.Competitions[0].Events[0].Markets["soccer.match_odds"].Submarkets["period=ft"].Selections
+	if len(selections) != 1 || selections[0].Price != 1.91 {
+		t.Fatalf("unexpected selections %+v", selections)
+	}
+}
+
+// TestGetUpcomingFixtures tests that only events with a cutoff within the window are returned
+func TestGetUpcomingFixtures(t *testing.T) {
+	now := time.Now().UTC()
+	cutoff := func(d time.Duration) string { return now.Add(d).Format(time.RFC3339) }
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		if r.URL.Path != "/pub/v2/odds/events" || query.Get("sport") != "soccer" || query.Get("from") == "" || query.Get("to") == "" {
+			t.Errorf("unexpected request %s", r.URL)
+		}
+		w.Write([]byte(`{"competitions":[
+			{"key":"a","events":[{"id":1,"cutoffTime":"` + cutoff(30*time.Minute) + `"},{"id":2,"cutoffTime":"` + cutoff(-time.Minute) + `"}]},
+			{"key":"b","events":[{"id":3,"cutoffTime":"` + cutoff(3*time.Hour) + `"}]}]}`))
+	}))
+	defer server.Close()
+
+	client := NewAPIClient(apikey, WithBaseURL(server.URL))
+
+	fixtures, err := client.GetUpcomingFixtures(context.Background(), "soccer", 2*time.Hour)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(fixtures.Competitions) != 1 || len(fixtures.Competitions[0].Events) != 1 || fixtures.Competitions[0].Events[0].ID != 1 {
+		t.Fatalf("unexpected fixtures %+v", fixtures)
+	}
+}
+
+// TestFixturesIterator tests that events are returned one by one across pages and competitions
+func TestFixturesIterator(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Query().Get("cursor") {
+		case "":
+			w.Write([]byte(`{"competitions":[{"key":"a","events":[{"id":1},{"id":2}]},{"key":"b","events":[]}],"cursor":"page-2"}`))
+		case "page-2":
+			w.Write([]byte(`{"competitions":[{"key":"b","events":[{"id":3}]}],"cursor":"page-3"}`))
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	defer server.Close()
+
+	client := NewAPIClient(apikey, WithBaseURL(server.URL))
+
+	it := client.FixturesIterator(context.Background(), "soccer", time.Now())
+	var ids []int
+	var competitions []CompetitionKey
+	for event, ok := it.Next(); ok; event, ok = it.Next() {
+		ids = append(ids, event.ID)
+		competitions = append(competitions, it.Competition().Key)
+	}
+	if len(ids) != 3 || ids[0] != 1 || ids[2] != 3 || competitions[1] != "a" || competitions[2] != "b" {
+		t.Fatalf("unexpected events %v in competitions %v", ids, competitions)
+	}
+	var apiErr *APIError
+	if !errors.As(it.Err(), &apiErr) {
+		t.Fatalf("expected the failure of the third page, got %v", it.Err())
+	}
+	if _, ok := it.Next(); ok {
+		t.Fatalf("expected the iteration to stay stopped")
+	}
+}
+
+// TestFixturesIteratorRepeatedCursor tests that the iteration stops when the server repeats the cursor
+func TestFixturesIteratorRepeatedCursor(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		if r.URL.Query().Get("cursor") == "" {
+			w.Write([]byte(`{"competitions":[{"key":"a","events":[{"id":1}]}],"cursor":"page-2"}`))
+			return
+		}
+		w.Write([]byte(`{"competitions":[],"cursor":"page-2"}`))
+	}))
+	defer server.Close()
+
+	client := NewAPIClient(apikey, WithBaseURL(server.URL))
+
+	it := client.FixturesIterator(context.Background(), "soccer", time.Now())
+	var ids []int
+	for event, ok := it.Next(); ok; event, ok = it.Next() {
+		ids = append(ids, event.ID)
+	}
+	if len(ids) != 1 || ids[0] != 1 {
+		t.Fatalf("unexpected events %v", ids)
+	}
+	if it.Err() == nil || requests.Load() != 2 {
+		t.Fatalf("expected an error after 2 requests, got %v after %d", it.Err(), requests.Load())
+	}
+}
+
+// TestGetEventsSince tests that only events changed after the sequence are returned with the new cursor
+func TestGetEventsSince(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/pub/v2/odds/events" || r.URL.Query().Get("sport") != "soccer" {
+			t.Errorf("unexpected request %s", r.URL)
+		}
+		w.Write([]byte(`{"competitions":[
+			{"key":"a","events":[{"id":1,"sequence":10},{"id":2,"sequence":25,"markets":{"soccer.match_odds":{"submarkets":{}}}}]},
+			{"key":"b","events":[{"id":3,"sequence":21}]}]}`))
+	}))
+	defer server.Close()
+
+	client := NewAPIClient(apikey, WithBaseURL(server.URL))
+	ctx := context.Background()
+
+	events, latest, err := client.GetEventsSince(ctx, "soccer", 20)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(events) != 2 || events[0].ID != 2 || events[1].ID != 3 || latest != 25 {
+		t.Fatalf("unexpected events %+v up to %d", events, latest)
+	}
+	if _, ok := events[0].Markets["soccer.match_odds"]; !ok {
+		t.Fatalf("expected the markets to be decoded")
+	}
+
+	events, latest, err = client.GetEventsSince(ctx, "soccer", latest)
+	if err != nil || len(events) != 0 || latest != 25 {
+		t.Fatalf("expected no changes, got %+v up to %d, %v", events, latest, err)
+	}
+}
+
+// TestGetFixturesRange tests that days are merged without duplicates and capped at the limit
+func TestGetFixturesRange(t *testing.T) {
+	days := map[string]string{
+		"2024-05-18": `{"competitions":[{"key":"a","events":[{"id":1},{"id":2}]}]}`,
+		"2024-05-19": `{"competitions":[{"key":"a","events":[{"id":2},{"id":3}]},{"key":"b","events":[{"id":4}]}]}`,
+		"2024-05-20": `{"competitions":[{"key":"c","events":[{"id":5}]}]}`,
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, ok := days[r.URL.Query().Get("date")]
+		if !ok {
+			t.Errorf("unexpected request %s", r.URL)
+		}
+		var fixtures Fixtures
+		json.Unmarshal([]byte(body), &fixtures)
+		limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+		for i, competition := range fixtures.Competitions {
+			fixtures.Competitions[i].Events = competition.Events[:min(len(competition.Events), limit)] // Cut each day at the limit, as Cloudbet does
+			limit -= len(fixtures.Competitions[i].Events)
+		}
+		json.NewEncoder(w).Encode(fixtures)
+	}))
+	defer server.Close()
+
+	client := NewAPIClient(apikey, WithBaseURL(server.URL))
+	ctx := context.Background()
+	from := time.Date(2024, 5, 18, 12, 0, 0, 0, time.UTC)
+	to := time.Date(2024, 5, 20, 8, 0, 0, 0, time.UTC)
+
+	fixtures, truncated, err := client.GetFixturesRange(ctx, "soccer", from, to, 10)
+	if err != nil || truncated {
+		t.Fatalf("expected every fixture, got %v, %v", truncated, err)
+	}
+	if len(fixtures.Competitions) != 3 || len(fixtures.Competitions[0].Events) != 3 || fixtures.Competitions[2].Events[0].ID != 5 {
+		t.Fatalf("unexpected fixtures %+v", fixtures)
+	}
+
+	fixtures, truncated, err = client.GetFixturesRange(ctx, "soccer", from, to, 4)
+	if err != nil || !truncated {
+		t.Fatalf("expected truncated fixtures, got %v, %v", truncated, err)
+	}
+	if len(fixtures.Competitions) != 2 || fixtures.Competitions[1].Events[0].ID != 4 {
+		t.Fatalf("unexpected truncated fixtures %+v", fixtures)
+	}
+
+	day := time.Date(2024, 5, 19, 0, 0, 0, 0, time.UTC)
+	fixtures, truncated, err = client.GetFixturesRange(ctx, "soccer", day, day, 2)
+	if err != nil || !truncated || len(fixtures.Competitions) != 1 || len(fixtures.Competitions[0].Events) != 2 {
+		t.Fatalf("expected a day cut at the limit to be truncated, got %+v, %v, %v", fixtures, truncated, err)
+	}
+	if _, truncated, err := client.GetFixturesRange(ctx, "soccer", from, from, 2); err != nil || truncated {
+		t.Fatalf("expected a day holding exactly the limit not to be truncated, got %v, %v", truncated, err)
+	}
+	if _, _, err := client.GetFixturesRange(ctx, "soccer", from, to, 0); err == nil {
+		t.Fatal("expected a zero limit to be rejected")
+	}
+}
+
+// TestEventsDetailed tests that a fixture entry resolves to its full event
+func TestEventsDetailed(t *testing.T) {
+	client, closeServer := newTestClient(nil)
+	defer closeServer()
+
+	event, err := Events{ID: 24055338}.Detailed(context.Background(), client)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if event.ID != 24055338 || event.Competition.Key != "soccer-england-premier-league" || len(event.Markets) == 0 {
+		t.Fatalf("unexpected event %+v", event)
+	}
+}
@@ -2,4 +2,8 @@ module github.com/Flagon00/CloudbetClient
 
 go 1.22.2
 
-require github.com/google/uuid v1.6.0
+require (
+	github.com/google/uuid v1.6.0
+	github.com/shopspring/decimal v1.4.0
+	golang.org/x/time v0.9.0
+)
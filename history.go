@@ -0,0 +1,105 @@
+package cloudbet
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Cloudbet does not expose historical odds, so price history is built by recording the odds stream
+
+// PricePoint is the price of a selection at one point in time
+type PricePoint struct {
+	Time     time.Time // Time the price was received, in UTC
+	Price    float64   // Price of the selection
+	Sequence int       // Sequence number of the update that carried the price
+}
+
+// PriceRecorder accumulates the price series of every selection seen on an odds stream, for line
+// movement analysis and backtesting. It is safe for concurrent use
+type PriceRecorder struct {
+	mu     sync.Mutex
+	series map[string][]PricePoint // Price points keyed by priceHistoryKey
+	now    func() time.Time        // Clock, replaced in tests
+}
+
+// NewPriceRecorder returns an empty PriceRecorder
+func NewPriceRecorder() *PriceRecorder {
+	return &PriceRecorder{series: make(map[string][]PricePoint), now: time.Now}
+}
+
+// priceHistoryKey identifies a selection, selection is its outcome optionally followed by its
+// params, e.g. "home" or "over?total=2.5"
+func priceHistoryKey(eventID, marketKey, selection string) string {
+	return eventID + "|" + marketKey + "|" + selection
+}
+
+// Record adds the price carried by an update to the series of its selection. Updates older than the
+// last recorded one (by sequence) are ignored, so every series is ordered by time and sequence
+func (r *PriceRecorder) Record(update OddsUpdate) {
+	selection := update.Outcome
+	if update.Params != "" {
+		selection += "?" + update.Params
+	}
+	key := priceHistoryKey(strconv.Itoa(update.EventID), update.MarketKey, selection)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	point := PricePoint{Time: r.now().UTC(), Price: update.Price, Sequence: update.Sequence}
+	if series := r.series[key]; len(series) > 0 {
+		last := series[len(series)-1]
+		if update.Sequence < last.Sequence {
+			return // Out of order update, a newer price is already known
+		}
+		if point.Time.Before(last.Time) {
+			point.Time = last.Time // Keep the series monotonic if the wall clock steps back
+		}
+	}
+	r.series[key] = append(r.series[key], point)
+}
+
+// Tee records every update of a stream such as the one returned by StreamOdds and forwards it on
+// the returned channel, which is closed once updates is or ctx is done. Cancel ctx when you stop
+// reading the returned channel, otherwise forwarding blocks and stalls the stream behind it
+func (r *PriceRecorder) Tee(ctx context.Context, updates <-chan OddsUpdate) <-chan OddsUpdate {
+	forwarded := make(chan OddsUpdate)
+	go func() {
+		defer close(forwarded)
+		for {
+			var update OddsUpdate
+			select {
+			case next, ok := <-updates:
+				if !ok {
+					return // The stream ended
+				}
+				update = next
+			case <-ctx.Done():
+				return
+			}
+			r.Record(update)
+			select {
+			case forwarded <- update:
+			case <-ctx.Done():
+				return // The caller stopped reading
+			}
+		}
+	}()
+	return forwarded
+}
+
+// GetPriceHistory returns the recorded prices of a selection in chronological order. selection is the
+// outcome optionally followed by its params, e.g. "over?total=2.5". It returns ErrSelectionNotFound
+// when no price was recorded for the selection
+func (r *PriceRecorder) GetPriceHistory(eventID, marketKey, selection string) ([]PricePoint, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	series, ok := r.series[priceHistoryKey(eventID, marketKey, selection)]
+	if !ok {
+		return nil, fmt.Errorf("no price history for %s/%s of event %s: %w", marketKey, selection, eventID, ErrSelectionNotFound)
+	}
+	return append([]PricePoint(nil), series...), nil // Copy so later updates do not race with the caller
+}
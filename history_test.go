@@ -0,0 +1,62 @@
+package cloudbet
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// TestPriceRecorder tests that streamed prices are recorded per selection in order
+func TestPriceRecorder(t *testing.T) {
+	recorder := NewPriceRecorder()
+	clock := time.Date(2024, 5, 19, 14, 0, 0, 0, time.FixedZone("CEST", 2*60*60))
+	recorder.now = func() time.Time {
+		clock = clock.Add(time.Minute)
+		return clock
+	}
+
+	updates := make(chan OddsUpdate, 4)
+	updates <- OddsUpdate{EventID: 1, MarketKey: "soccer.total_goals", Outcome: "over", Params: "total=2.5", Price: 1.8, Sequence: 1}
+	updates <- OddsUpdate{EventID: 1, MarketKey: "soccer.total_goals", Outcome: "over", Params: "total=2.5", Price: 1.85, Sequence: 3}
+	updates <- OddsUpdate{EventID: 1, MarketKey: "soccer.total_goals", Outcome: "over", Params: "total=2.5", Price: 1.7, Sequence: 2} // Late, dropped
+	updates <- OddsUpdate{EventID: 1, MarketKey: "soccer.match_odds", Outcome: "home", Price: 1.9, Sequence: 4}
+	close(updates)
+	for range recorder.Tee(context.Background(), updates) {
+	}
+
+	history, err := recorder.GetPriceHistory("1", "soccer.total_goals", "over?total=2.5")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(history) != 2 || history[0].Price != 1.8 || history[1].Price != 1.85 {
+		t.Fatalf("unexpected history %+v", history)
+	}
+	if history[0].Time.Location() != time.UTC || !history[0].Time.Before(history[1].Time) {
+		t.Fatalf("expected ordered UTC timestamps, got %+v", history)
+	}
+
+	if _, err := recorder.GetPriceHistory("1", "soccer.match_odds", "away"); !errors.Is(err, ErrSelectionNotFound) {
+		t.Fatalf("expected ErrSelectionNotFound, got %v", err)
+	}
+}
+
+// TestPriceRecorderTeeCancel tests that Tee stops forwarding once its context is done
+func TestPriceRecorderTeeCancel(t *testing.T) {
+	recorder := NewPriceRecorder()
+	updates := make(chan OddsUpdate, 1) // Never closed, only the context can end forwarding
+	updates <- OddsUpdate{EventID: 1, MarketKey: "soccer.match_odds", Outcome: "home", Price: 1.9}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	forwarded := recorder.Tee(ctx, updates)
+	cancel() // Stop reading without draining the forwarded update
+
+	select {
+	case _, ok := <-forwarded:
+		if ok {
+			<-forwarded // The pending update may still be forwarded before the close
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatalf("expected the forwarded channel to be closed")
+	}
+}
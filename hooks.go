@@ -0,0 +1,44 @@
+package cloudbet
+
+import "net/http"
+
+// WithRequestHook registers a function called with every request right before it is sent,
+// for example to add tracing headers. Hooks run in registration order on every attempt
+func WithRequestHook(hook func(*http.Request)) Option {
+	return func(c *APIClient) {
+		c.requestHooks = append(c.requestHooks, hook)
+	}
+}
+
+// WithResponseHook registers a function called with every response as soon as it is received,
+// before its body is read. Hooks must not consume or close the body
+func WithResponseHook(hook func(*http.Response)) Option {
+	return func(c *APIClient) {
+		c.responseHooks = append(c.responseHooks, hook)
+	}
+}
+
+// WithRoundTripper sets the transport of the HTTP client, use it to wrap the default transport
+// with middleware such as OpenTelemetry instrumentation
+func WithRoundTripper(rt http.RoundTripper) Option {
+	return func(c *APIClient) {
+		client := *c.Client // Copy the client so a caller supplied one is never mutated
+		client.Transport = rt
+		c.Client = &client
+		c.insecureSkipVerify = false // The transport of an earlier WithInsecureSkipVerify is replaced
+	}
+}
+
+// runRequestHooks calls the registered request hooks
+func (c *APIClient) runRequestHooks(req *http.Request) {
+	for _, hook := range c.requestHooks {
+		hook(req)
+	}
+}
+
+// runResponseHooks calls the registered response hooks
+func (c *APIClient) runResponseHooks(resp *http.Response) {
+	for _, hook := range c.responseHooks {
+		hook(resp)
+	}
+}
@@ -0,0 +1,54 @@
+package cloudbet
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+)
+
+// roundTripperFunc adapts a function to http.RoundTripper
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+// RoundTrip implements http.RoundTripper
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// TestHooks tests that hooks and the round tripper run for every core method
+func TestHooks(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Trace-Id") != "trace-1" {
+			t.Errorf("missing tracing header on %s", r.URL.Path)
+		}
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	var requests, responses, trips atomic.Int32
+	client := NewAPIClient(apikey,
+		WithBaseURL(server.URL),
+		WithRoundTripper(roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			trips.Add(1)
+			return http.DefaultTransport.RoundTrip(req)
+		})),
+		WithRequestHook(func(req *http.Request) {
+			requests.Add(1)
+			req.Header.Set("X-Trace-Id", "trace-1")
+		}),
+		WithResponseHook(func(resp *http.Response) {
+			responses.Add(1)
+		}),
+	)
+	ctx := context.Background()
+
+	client.PlaceBet(ctx, PlaceBetPayload{})
+	client.AccountBalance(ctx, "PLAY_EUR")
+	client.GetTodayFixtures(ctx, "soccer", 10)
+	client.GetEvent(ctx, "1")
+
+	if requests.Load() != 4 || responses.Load() != 4 || trips.Load() != 4 {
+		t.Fatalf("expected 4 calls of each hook, got %d requests, %d responses, %d trips", requests.Load(), responses.Load(), trips.Load())
+	}
+}
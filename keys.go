@@ -0,0 +1,20 @@
+package cloudbet
+
+// SportKey is the key of a sport, e.g. "soccer"
+type SportKey string
+
+// CompetitionKey is the key of a competition, e.g. "soccer-england-premier-league"
+type CompetitionKey string
+
+// EventKey is the key of an event, e.g. "c-arsenal-v-c-chelsea". Events are also identified by
+// numeric IDs, which are passed as strings and are not keys
+type EventKey string
+
+// String returns the key as sent to Cloudbet
+func (k SportKey) String() string { return string(k) }
+
+// String returns the key as sent to Cloudbet
+func (k CompetitionKey) String() string { return string(k) }
+
+// String returns the key as sent to Cloudbet
+func (k EventKey) String() string { return string(k) }
@@ -0,0 +1,30 @@
+package cloudbet
+
+import (
+	"context"
+	"testing"
+)
+
+// TestTypedKeys tests that keys decode into their types and can be passed back to the client
+func TestTypedKeys(t *testing.T) {
+	client, closeServer := newTestClient(nil)
+	defer closeServer()
+
+	event, err := client.GetEventJSON(context.Background(), "24055338")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	var (
+		eventKey       EventKey       = event.Key
+		competitionKey CompetitionKey = event.Competition.Key
+		sportKey       SportKey       = event.EventSport.Key
+	)
+	if eventKey != "c-arsenal-v-c-chelsea" || competitionKey != "soccer-england-premier-league" || sportKey.String() != "soccer" {
+		t.Fatalf("unexpected keys %q, %q, %q", eventKey, competitionKey, sportKey)
+	}
+
+	byKey, err := client.GetEventByKey(context.Background(), eventKey)
+	if err != nil || byKey.ID != event.ID {
+		t.Fatalf("expected the same event by key, got %+v, %v", byKey, err)
+	}
+}
@@ -0,0 +1,54 @@
+package cloudbet
+
+import "context"
+
+// Close stops the odds streams of the client, closes its idle connections and makes every later
+// call fail with ErrClosed. Requests already in flight are allowed to finish. Close is idempotent
+func (c *APIClient) Close() error {
+	c.mu.Lock()
+	if c.closed {
+		c.mu.Unlock()
+		return nil // Already closed
+	}
+	c.closed = true
+	close(c.doneLocked()) // Wake up the background goroutines
+	c.mu.Unlock()
+
+	if c.Client != nil && c.Client.Transport != nil {
+		c.Client.CloseIdleConnections() // Release the pooled connections of the transport, never those shared by http.DefaultTransport
+	}
+	return nil
+}
+
+// isClosed reports whether Close has been called
+func (c *APIClient) isClosed() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.closed
+}
+
+// doneLocked returns the channel closed by Close, c.mu must be held
+func (c *APIClient) doneLocked() chan struct{} {
+	if c.done == nil {
+		c.done = make(chan struct{}) // Created lazily so a zero APIClient still works
+	}
+	return c.done
+}
+
+// withClose returns a copy of ctx that is also cancelled when the client is closed,
+// for background goroutines that outlive a single call
+func (c *APIClient) withClose(ctx context.Context) (context.Context, context.CancelFunc) {
+	c.mu.Lock()
+	done := c.doneLocked()
+	c.mu.Unlock()
+
+	ctx, cancel := context.WithCancel(ctx)
+	go func() {
+		select {
+		case <-done:
+			cancel() // The client was closed
+		case <-ctx.Done():
+		}
+	}()
+	return ctx, cancel
+}
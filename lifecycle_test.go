@@ -0,0 +1,72 @@
+package cloudbet
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// TestClose tests that Close stops streams and makes later calls fail with ErrClosed
+func TestClose(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/pub/v2/odds/stream" {
+			w.Write([]byte(`{"amount":"1"}`))
+			return
+		}
+		w.Header().Set("Content-Type", "text/event-stream")
+		fmt.Fprint(w, ": heartbeat\n\n")
+		w.(http.Flusher).Flush()
+		<-r.Context().Done() // Keep the stream open until the client goes away
+	}))
+	defer server.Close()
+
+	client := NewAPIClient(apikey, WithBaseURL(server.URL))
+	ctx := context.Background()
+
+	if _, err := client.AccountBalance(ctx, "PLAY_EUR"); err != nil {
+		t.Fatalf("expected no error before Close, got %v", err)
+	}
+	updates, err := client.StreamOdds(ctx, "soccer")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if err := client.Close(); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if err := client.Close(); err != nil {
+		t.Fatalf("expected a second Close to succeed, got %v", err)
+	}
+
+	select {
+	case _, ok := <-updates:
+		if ok {
+			t.Fatalf("expected no update after Close")
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatalf("timed out waiting for the stream to stop")
+	}
+
+	if _, err := client.AccountBalance(ctx, "PLAY_EUR"); !errors.Is(err, ErrClosed) {
+		t.Fatalf("expected ErrClosed, got %v", err)
+	}
+	if _, err := client.StreamOdds(ctx, "soccer"); !errors.Is(err, ErrClosed) {
+		t.Fatalf("expected ErrClosed, got %v", err)
+	}
+}
+
+// TestCloseZeroClient tests that Close does not panic on a client built without NewAPIClient
+func TestCloseZeroClient(t *testing.T) {
+	for _, client := range []*APIClient{{}, {Client: &http.Client{}}} {
+		if err := client.Close(); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if !client.isClosed() {
+			t.Fatalf("expected the client to be closed")
+		}
+	}
+}
@@ -0,0 +1,43 @@
+package cloudbet
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+)
+
+// Logger receives debug traces of the requests made by the client
+type Logger interface {
+	Logf(format string, args ...any)
+}
+
+// WithLogger traces the method, URL, status and latency of every request to logger.
+// The API key is never logged
+func WithLogger(logger Logger) Option {
+	return func(c *APIClient) {
+		c.logger = logger
+	}
+}
+
+// slogLogger adapts a slog.Logger to the Logger interface
+type slogLogger struct {
+	logger *slog.Logger // Logger receiving the traces
+}
+
+// NewSlogLogger returns a Logger writing traces to l at debug level
+func NewSlogLogger(l *slog.Logger) Logger {
+	return slogLogger{logger: l}
+}
+
+// Logf implements Logger
+func (l slogLogger) Logf(format string, args ...any) {
+	l.logger.Log(context.Background(), slog.LevelDebug, fmt.Sprintf(format, args...))
+}
+
+// logf writes a trace to the configured logger, redacting the API key
+func (c *APIClient) logf(format string, args ...any) {
+	if c.logger == nil {
+		return // Logging is disabled
+	}
+	c.logger.Logf("%s", c.redact(fmt.Sprintf(format, args...))) // Never let the key reach the logs
+}
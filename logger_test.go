@@ -0,0 +1,50 @@
+package cloudbet
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// recordingLogger collects the traces written by the client
+type recordingLogger struct {
+	mu    sync.Mutex
+	lines []string
+}
+
+// Logf implements Logger
+func (l *recordingLogger) Logf(format string, args ...any) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.lines = append(l.lines, fmt.Sprintf(format, args...))
+}
+
+// TestWithLogger tests that requests are traced without the API key
+func TestWithLogger(t *testing.T) {
+	const secret = "sk_live_secret_1234"
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	logger := &recordingLogger{}
+	client := NewAPIClient(secret, WithBaseURL(server.URL), WithLogger(logger))
+
+	if _, err := client.GetEvent(context.Background(), secret); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(logger.lines) != 1 {
+		t.Fatalf("expected 1 trace, got %v", logger.lines)
+	}
+	line := logger.lines[0]
+	if !strings.Contains(line, "GET") || !strings.Contains(line, "/pub/v2/odds/events/") || !strings.Contains(line, "200") {
+		t.Fatalf("unexpected trace %q", line)
+	}
+	if strings.Contains(line, secret) {
+		t.Fatalf("trace leaks the API key: %q", line)
+	}
+}
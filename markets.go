@@ -0,0 +1,223 @@
+package cloudbet
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// BuildMarketURL builds the market URL of a selection in the format Cloudbet expects,
+// "<sport>.<market>/<outcome>?<params>", e.g. "soccer.total_goals/over?total=2.5".
+// marketKey may be given with or without the sport prefix and params must be "key=value" pairs
+func BuildMarketURL(sportKey, marketKey, outcome string, params ...string) (string, error) {
+	if err := validateMarketURLPart("sport key", sportKey); err != nil {
+		return "", err
+	}
+	if err := validateMarketURLPart("market key", marketKey); err != nil {
+		return "", err
+	}
+	if err := validateMarketURLPart("outcome", outcome); err != nil {
+		return "", err
+	}
+	if !strings.HasPrefix(marketKey, sportKey+".") {
+		marketKey = sportKey + "." + marketKey // Prefix the market with its sport
+	}
+
+	marketURL := marketKey + "/" + outcome
+	for i, param := range params {
+		key, value, ok := strings.Cut(param, "=")
+		if !ok || key == "" || value == "" || strings.ContainsAny(param, "/?& ") {
+			return "", fmt.Errorf("invalid market URL param %q, expected key=value", param) // Return error if the param is malformed
+		}
+		if i == 0 {
+			marketURL += "?" + param
+		} else {
+			marketURL += "&" + param
+		}
+	}
+	return marketURL, nil
+}
+
+// validateMarketURLPart checks that a market URL component is set and has no reserved characters
+func validateMarketURLPart(name, value string) error {
+	if value == "" {
+		return fmt.Errorf("invalid market URL: %s is empty", name)
+	}
+	if strings.ContainsAny(value, "/?&= \t") {
+		return fmt.Errorf("invalid market URL: %s %q contains a reserved character", name, value)
+	}
+	return nil
+}
+
+// parseMarketURL splits a market URL such as "soccer.total_goals/over?total=2.5" into its
+// market key, outcome and params
+func parseMarketURL(marketURL string) (marketKey, outcome, params string, err error) {
+	path, params, _ := strings.Cut(marketURL, "?")
+	marketKey, outcome, ok := strings.Cut(path, "/")
+	if !ok || marketKey == "" || outcome == "" {
+		return "", "", "", fmt.Errorf("invalid market URL %q, expected <market>/<outcome>[?<params>]", marketURL)
+	}
+	return marketKey, outcome, params, nil
+}
+
+// FindSelection returns the selection of an event market with the given outcome and params
+// (e.g. "soccer.total_goals", "over", "total=2.5"), or an error matching ErrSelectionNotFound
+func (e *Event) FindSelection(marketKey, outcome, params string) (Selections, error) {
+	market, ok := e.Markets[marketKey]
+	if !ok {
+		return Selections{}, fmt.Errorf("market %s of event %d: %w", marketKey, e.ID, ErrSelectionNotFound)
+	}
+	keys := make([]string, 0, len(market.Submarkets))
+	for key := range market.Submarkets {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys) // Search submarkets in a stable order
+	for _, key := range keys {
+		for _, selection := range market.Submarkets[key].Selections {
+			if selection.Outcome == outcome && selection.Params == params {
+				return selection, nil
+			}
+		}
+	}
+	return Selections{}, fmt.Errorf("selection %s/%s?%s of event %d: %w", marketKey, outcome, params, e.ID, ErrSelectionNotFound)
+}
+
+// GetBestPrice retrieves an event and returns the enabled selection with the highest price for
+// the outcome across the submarkets of a market. Use FindSelection for lines identified by params
+func (c *APIClient) GetBestPrice(ctx context.Context, eventID, marketKey, outcome string) (Selections, error) {
+	event, err := c.GetEventJSON(ctx, eventID)
+	if err != nil {
+		return Selections{}, err
+	}
+
+	var best Selections
+	found := false
+	for _, submarket := range event.Markets[marketKey].Submarkets {
+		for _, selection := range submarket.Selections {
+			if selection.Outcome == outcome && selection.Status == SelectionStatusEnabled && (!found || selection.Price > best.Price) {
+				best, found = selection, true
+			}
+		}
+	}
+	if !found {
+		return Selections{}, fmt.Errorf("selection %s/%s of event %s: %w", marketKey, outcome, eventID, ErrSelectionNotFound)
+	}
+	return best, nil
+}
+
+// TradingMarkets returns the markets of the event keeping only their enabled selections. Submarkets
+// and markets left without selections are dropped
+func (e *Event) TradingMarkets() map[string]Market {
+	trading := make(map[string]Market)
+	for marketKey, market := range e.Markets {
+		submarkets := make(map[string]Submarket)
+		for key, submarket := range market.Submarkets {
+			var selections []Selections
+			for _, selection := range submarket.Selections {
+				if selection.Status == SelectionStatusEnabled {
+					selections = append(selections, selection) // Suspended selections cannot be bet on
+				}
+			}
+			if len(selections) > 0 {
+				submarket.Selections = selections
+				submarkets[key] = submarket
+			}
+		}
+		if len(submarkets) > 0 {
+			trading[marketKey] = Market{Submarkets: submarkets}
+		}
+	}
+	return trading
+}
+
+// IsTradable reports whether the selection can be bet on right now: it is enabled and priced. Bets on
+// other selections are rejected, so filtering with it saves a PlaceBet call and its rate limit
+func (s Selections) IsTradable() bool {
+	return s.Status == SelectionStatusEnabled && s.Price > 0
+}
+
+// TradableSelections returns the selections of every submarket that can be bet on, see IsTradable.
+// Submarkets are visited in key order
+func (m Market) TradableSelections() []Selections {
+	keys := make([]string, 0, len(m.Submarkets))
+	for key := range m.Submarkets {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys) // Return selections in a stable order
+
+	var tradable []Selections
+	for _, key := range keys {
+		for _, selection := range m.Submarkets[key].Selections {
+			if selection.IsTradable() {
+				tradable = append(tradable, selection)
+			}
+		}
+	}
+	return tradable
+}
+
+// GetEventLiveMarkets retrieves an event with only its currently trading markets and enabled selections,
+// for lighter scanning. Cloudbet offers no such filter, so the event is filtered once decoded
+func (c *APIClient) GetEventLiveMarkets(ctx context.Context, id string) (*Event, error) {
+	event, err := c.GetEventJSON(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	event.Markets = event.TradingMarkets()
+	return event, nil
+}
+
+// GetMarketAcrossEvents retrieves one market (e.g. "soccer.match_odds") of several events concurrently,
+// asking Cloudbet for that market only, keyed by event ID. Events without the market are absent from
+// the map. Events that could not be fetched are absent too and reported in the joined error, so the
+// markets fetched are usable even when the error is not nil
+func (c *APIClient) GetMarketAcrossEvents(ctx context.Context, marketKey string, eventIDs []string) (map[string]Market, error) {
+	events, errs := c.GetEvents(ctx, eventIDs, marketKey)
+
+	markets := make(map[string]Market, len(events))
+	for id, event := range events {
+		if market, ok := event.Markets[marketKey]; ok {
+			markets[id] = market
+		}
+	}
+
+	ids := make([]string, 0, len(errs))
+	for id := range errs {
+		ids = append(ids, id)
+	}
+	slices.Sort(ids) // Report failures in a stable order
+	failures := make([]error, 0, len(ids))
+	for _, id := range ids {
+		failures = append(failures, fmt.Errorf("event %s: %w", id, errs[id]))
+	}
+	return markets, errors.Join(failures...)
+}
+
+// PlaceBetOn places a bet on a selection of a fetched event at its current price, building the payload
+// from the event. selection is the outcome followed by its params, as in a market URL (e.g. "home" or
+// "over?total=2.5"). Suspended selections fail with ErrMarketSuspended without sending anything. The bet
+// is placed in the default currency with a generated reference ID, returned on the response
+func (c *APIClient) PlaceBetOn(ctx context.Context, event *Event, marketKey, selection string, stake Money, policy PriceChangePolicy) (*PlaceBetResponse, error) {
+	outcome, params, _ := strings.Cut(selection, "?")
+	found, err := event.FindSelection(marketKey, outcome, params)
+	if err != nil {
+		return nil, err // Return error if the event has no such selection
+	}
+	if !found.IsTradable() {
+		return nil, fmt.Errorf("selection %s/%s of event %d is %s: %w", marketKey, selection, event.ID, found.Status, ErrMarketSuspended)
+	}
+
+	return c.PlaceBet(ctx, PlaceBetPayload{
+		PriceChange: policy,
+		EventId:     strconv.Itoa(event.ID),
+		MarketURL:   marketKey + "/" + selection,
+		Price:       found.PriceMoney(),
+		UUID:        uuid.NewString(),
+		Stake:       stake,
+	})
+}
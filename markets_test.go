@@ -0,0 +1,191 @@
+package cloudbet
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// TestBuildMarketURL tests valid and malformed market URLs
+func TestBuildMarketURL(t *testing.T) {
+	tests := []struct {
+		sport, market, outcome string
+		params                 []string
+		want                   string
+		wantErr                bool
+	}{
+		{"soccer", "match_odds", "away", nil, "soccer.match_odds/away", false},
+		{"soccer", "soccer.match_odds", "home", nil, "soccer.match_odds/home", false},
+		{"soccer", "asian_handicap", "home", []string{"handicap=-0.5", "period=ft"}, "soccer.asian_handicap/home?handicap=-0.5&period=ft", false},
+		{"soccer", "total_goals", "over", []string{"total=2.5"}, "soccer.total_goals/over?total=2.5", false},
+		{"", "match_odds", "home", nil, "", true},
+		{"soccer", "match_odds", "", nil, "", true},
+		{"soccer", "match_odds", "home/away", nil, "", true},
+		{"soccer", "1x2", "x", nil, "soccer.1x2/x", false},
+		{"soccer", "correct_score", "2", []string{"score=2-1"}, "soccer.correct_score/2?score=2-1", false},
+		{"basketball", "player_points", "12345", []string{"total=20.5"}, "basketball.player_points/12345?total=20.5", false},
+		{"soccer", "total_goals", "over", []string{"2.5"}, "", true},
+	}
+	for _, tt := range tests {
+		got, err := BuildMarketURL(tt.sport, tt.market, tt.outcome, tt.params...)
+		if (err != nil) != tt.wantErr {
+			t.Fatalf("BuildMarketURL(%q, %q, %q, %v): unexpected error %v", tt.sport, tt.market, tt.outcome, tt.params, err)
+		}
+		if got != tt.want {
+			t.Fatalf("BuildMarketURL(%q, %q, %q, %v) = %q, want %q", tt.sport, tt.market, tt.outcome, tt.params, got, tt.want)
+		}
+	}
+}
+
+// TestFindSelection tests looking up selections of a parsed event
+func TestFindSelection(t *testing.T) {
+	client, closeServer := newTestClient(nil)
+	defer closeServer()
+
+	event, err := client.GetEventJSON(context.Background(), "24055338")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	selection, err := event.FindSelection("soccer.total_goals", "under", "total=2.5")
+	if err != nil || selection.Price != 2.05 {
+		t.Fatalf("unexpected selection %+v, %v", selection, err)
+	}
+	if _, err := event.FindSelection("soccer.total_goals", "under", "total=3.5"); !errors.Is(err, ErrSelectionNotFound) {
+		t.Fatalf("expected ErrSelectionNotFound, got %v", err)
+	}
+	if _, err := event.FindSelection("soccer.corners", "over", ""); !errors.Is(err, ErrSelectionNotFound) {
+		t.Fatalf("expected ErrSelectionNotFound, got %v", err)
+	}
+}
+
+// TestGetBestPrice tests that suspended selections are not returned
+func TestGetBestPrice(t *testing.T) {
+	client, closeServer := newTestClient(nil)
+	defer closeServer()
+	ctx := context.Background()
+
+	selection, err := client.GetBestPrice(ctx, "24055338", "soccer.match_odds", "home")
+	if err != nil || selection.Price != 1.91 {
+		t.Fatalf("unexpected selection %+v, %v", selection, err)
+	}
+	if _, err := client.GetBestPrice(ctx, "24055338", "soccer.match_odds", "away"); !errors.Is(err, ErrSelectionNotFound) {
+		t.Fatalf("expected ErrSelectionNotFound for a suspended selection, got %v", err)
+	}
+}
+
+// TestGetEventLiveMarkets tests that suspended selections are filtered out
+func TestGetEventLiveMarkets(t *testing.T) {
+	client, closeServer := newTestClient(nil)
+	defer closeServer()
+
+	event, err := client.GetEventLiveMarkets(context.Background(), "24055338")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	selections := event.Markets["soccer.match_odds"].Submarkets["period=ft"].Selections
+	if len(selections) != 2 || selections[0].Outcome != "home" || selections[1].Outcome != "draw" {
+		t.Fatalf("expected the suspended away selection to be dropped, got %+v", selections)
+	}
+	if _, err := event.FindSelection("soccer.total_goals", "over", "total=2.5"); err != nil {
+		t.Fatalf("expected enabled lines to be kept, got %v", err)
+	}
+}
+
+// TestTradableSelections tests that suspended, disabled and unpriced selections are filtered out
+func TestTradableSelections(t *testing.T) {
+	market := Market{Submarkets: map[string]Submarket{
+		"period=ht": {Selections: []Selections{
+			{Outcome: "home", Price: 2.4, Status: SelectionStatusEnabled},
+			{Outcome: "away", Price: 3.1, Status: SelectionStatusDisabled},
+		}},
+		"period=ft": {Selections: []Selections{
+			{Outcome: "home", Price: 1.8, Status: SelectionStatusEnabled},
+			{Outcome: "draw", Price: 0, Status: SelectionStatusEnabled},
+			{Outcome: "away", Price: 4.2, Status: SelectionStatusSuspended},
+		}},
+	}}
+
+	tradable := market.TradableSelections()
+	if len(tradable) != 2 || tradable[0].Price != 1.8 || tradable[1].Price != 2.4 {
+		t.Fatalf("expected the enabled and priced selections in submarket order, got %+v", tradable)
+	}
+	if (Selections{Status: SelectionStatusUnknown, Price: 2}).IsTradable() {
+		t.Fatal("expected a selection of unknown status not to be tradable")
+	}
+}
+
+// TestGetMarketAcrossEvents tests that missing markets are absent and failed events reported
+func TestGetMarketAcrossEvents(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if markets := r.URL.Query()["markets"]; len(markets) != 1 || markets[0] != "soccer.match_odds" {
+			t.Errorf("unexpected market filter %v", markets)
+		}
+		switch strings.TrimPrefix(r.URL.Path, "/pub/v2/odds/events/") {
+		case "1":
+			w.Write([]byte(`{"id":1,"markets":{"soccer.match_odds":{"submarkets":{"period=ft":{"selections":[{"outcome":"home","price":1.9}]}}}}}`))
+		case "2":
+			w.Write([]byte(`{"id":2,"markets":{}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client := NewAPIClient(apikey, WithBaseURL(server.URL))
+
+	markets, err := client.GetMarketAcrossEvents(context.Background(), "soccer.match_odds", []string{"1", "2", "3"})
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusNotFound || !strings.Contains(err.Error(), "event 3") {
+		t.Fatalf("expected the failure of event 3, got %v", err)
+	}
+	if len(markets) != 1 || markets["1"].Submarkets["period=ft"].Selections[0].Price != 1.9 {
+		t.Fatalf("unexpected markets %+v", markets)
+	}
+}
+
+// TestPlaceBetOn tests that the payload is built from the event and suspended selections are refused
+func TestPlaceBetOn(t *testing.T) {
+	var payloads []PlaceBetPayload
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var payload PlaceBetPayload
+		json.NewDecoder(r.Body).Decode(&payload)
+		payloads = append(payloads, payload)
+		json.NewEncoder(w).Encode(PlaceBetResponse{ReferenceID: payload.UUID, Status: BetStatusAccepted})
+	}))
+	defer server.Close()
+
+	feed, closeFeed := newTestClient(nil)
+	defer closeFeed()
+	event, err := feed.GetEventJSON(context.Background(), "24055338")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	client := NewAPIClient(apikey, WithBaseURL(server.URL), WithDefaultCurrency("PLAY_EUR"))
+	ctx := context.Background()
+
+	bet, err := client.PlaceBetOn(ctx, event, "soccer.total_goals", "over?total=2.5", MustMoney("2"), PriceChangeBetter)
+	if err != nil || bet.Status != BetStatusAccepted {
+		t.Fatalf("expected the bet to be accepted, got %+v, %v", bet, err)
+	}
+	payload := payloads[0]
+	if payload.EventId != "24055338" || payload.MarketURL != "soccer.total_goals/over?total=2.5" || payload.Price.String() != "1.8" ||
+		payload.Currency != "PLAY_EUR" || payload.PriceChange != PriceChangeBetter || payload.UUID == "" || payload.UUID != bet.ReferenceID {
+		t.Fatalf("unexpected payload %+v", payload)
+	}
+
+	if _, err := client.PlaceBetOn(ctx, event, "soccer.match_odds", "away", MustMoney("2"), PriceChangeNone); !errors.Is(err, ErrMarketSuspended) {
+		t.Fatalf("expected ErrMarketSuspended, got %v", err)
+	}
+	if _, err := client.PlaceBetOn(ctx, event, "soccer.match_odds", "nobody", MustMoney("2"), PriceChangeNone); !errors.Is(err, ErrSelectionNotFound) {
+		t.Fatalf("expected ErrSelectionNotFound, got %v", err)
+	}
+	if len(payloads) != 1 {
+		t.Fatalf("expected only one bet to be sent, got %d", len(payloads))
+	}
+}
@@ -0,0 +1,40 @@
+package cloudbet
+
+import (
+	"errors"
+	"net/http"
+	"time"
+)
+
+// Collector receives a measurement for every call the client makes to Cloudbet, it lets metrics
+// such as Prometheus counters and histograms be wired without this library depending on them.
+// ObserveRequest may be called from several goroutines at once
+type Collector interface {
+	// ObserveRequest is called once per API call, after any retries. method is the APIClient method
+	// that made the call (e.g. "PlaceBet"), statusCode the final HTTP status or 0 when no response was
+	// received, and err the network or client error, nil when a response was received. Methods built on
+	// others, such as GetEvents or PlaceBetOn, report their calls under the method they use
+	// (GetEventJSON, PlaceBet)
+	ObserveRequest(method string, statusCode int, duration time.Duration, err error)
+}
+
+// WithMetrics makes the client report every API call to collector
+func WithMetrics(collector Collector) Option {
+	return func(c *APIClient) {
+		c.metrics = collector
+	}
+}
+
+// observe reports a finished API call to the collector
+func (c *APIClient) observe(method string, start time.Time, resp *http.Response, err error) {
+	statusCode := 0
+	var rateLimitErr *RateLimitError
+	switch {
+	case resp != nil:
+		statusCode = resp.StatusCode
+	case errors.As(err, &rateLimitErr):
+		statusCode = rateLimitErr.Err.StatusCode // The rate limited response was consumed by do
+		err = nil
+	}
+	c.metrics.ObserveRequest(method, statusCode, time.Since(start), err)
+}
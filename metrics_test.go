@@ -0,0 +1,80 @@
+package cloudbet
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+// observation is one call recorded by recordingCollector
+type observation struct {
+	method     string
+	statusCode int
+	err        error
+}
+
+// recordingCollector records the calls it observes
+type recordingCollector struct {
+	mu           sync.Mutex
+	observations []observation
+}
+
+// ObserveRequest implements Collector
+func (r *recordingCollector) ObserveRequest(method string, statusCode int, duration time.Duration, err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.observations = append(r.observations, observation{method, statusCode, err})
+}
+
+// TestWithMetrics tests that every method reports its calls, telling failures from successes
+func TestWithMetrics(t *testing.T) {
+	collector := &recordingCollector{}
+	client, closeServer := newTestClient(nil, WithMetrics(collector))
+	ctx := context.Background()
+
+	client.PlaceBet(ctx, PlaceBetPayload{UUID: "ref-1", Price: MustMoney("1.91"), Stake: MustMoney("10")})
+	client.AccountBalance(ctx, "PLAY_EUR")
+	client.GetTodayFixturesJSON(ctx, "soccer", 10)
+	client.GetEvent(ctx, "24055338")
+	client.GetBetStatus(ctx, "")
+	closeServer()
+	client.GetEvent(ctx, "24055338")
+
+	want := []observation{
+		{"PlaceBet", http.StatusOK, nil},
+		{"AccountBalance", http.StatusOK, nil},
+		{"GetTodayFixturesJSON", http.StatusOK, nil},
+		{"GetEvent", http.StatusOK, nil},
+		{"GetBetStatus", http.StatusNotFound, nil},
+	}
+	if len(collector.observations) != len(want)+1 {
+		t.Fatalf("expected %d observations, got %+v", len(want)+1, collector.observations)
+	}
+	for i, w := range want {
+		if got := collector.observations[i]; got.method != w.method || got.statusCode != w.statusCode || got.err != nil {
+			t.Fatalf("observation %d: expected %+v, got %+v", i, w, got)
+		}
+	}
+	if last := collector.observations[len(want)]; last.method != "GetEvent" || last.statusCode != 0 || last.err == nil {
+		t.Fatalf("expected a network error for GetEvent, got %+v", last)
+	}
+}
+
+// TestWithMetricsRateLimited tests that a rate limited call is reported with its status
+func TestWithMetricsRateLimited(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	collector := &recordingCollector{}
+	client := NewAPIClient(apikey, WithBaseURL(server.URL), WithMetrics(collector))
+	client.AccountBalance(context.Background(), "PLAY_EUR")
+
+	if len(collector.observations) != 1 || collector.observations[0].statusCode != http.StatusTooManyRequests {
+		t.Fatalf("expected one 429 observation, got %+v", collector.observations)
+	}
+}
@@ -0,0 +1,204 @@
+package cloudbet
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	"github.com/shopspring/decimal"
+)
+
+// Money is an exact decimal amount used for stakes, prices, balances and returns.
+// It is encoded as a JSON string (e.g. "1.5") which is the format Cloudbet uses
+type Money struct {
+	d decimal.Decimal // Underlying decimal value
+}
+
+// NewMoney parses a decimal string such as "1.50" into Money
+func NewMoney(value string) (Money, error) {
+	d, err := decimal.NewFromString(value)
+	if err != nil {
+		return Money{}, fmt.Errorf("invalid money value %q: %w", value, err) // Return error if the value is not a decimal
+	}
+	return Money{d: d}, nil
+}
+
+// MustMoney is like NewMoney but panics on invalid input, it is meant for constants
+func MustMoney(value string) Money {
+	m, err := NewMoney(value)
+	if err != nil {
+		panic(err)
+	}
+	return m
+}
+
+// MoneyFromInt returns Money holding the given whole amount
+func MoneyFromInt(value int64) Money {
+	return Money{d: decimal.NewFromInt(value)}
+}
+
+// String returns the decimal representation without trailing zeros
+func (m Money) String() string {
+	return m.d.String()
+}
+
+// StringFixed returns the decimal representation rounded to the given number of places
+func (m Money) StringFixed(places int32) string {
+	return m.d.StringFixed(places)
+}
+
+// Float64 returns the nearest float64, use it for display or statistics only
+func (m Money) Float64() float64 {
+	f, _ := m.d.Float64()
+	return f
+}
+
+// IsZero reports whether the amount is zero
+func (m Money) IsZero() bool {
+	return m.d.IsZero()
+}
+
+// Cmp compares two amounts and returns -1, 0 or +1
+func (m Money) Cmp(other Money) int {
+	return m.d.Cmp(other.d)
+}
+
+// Add returns m + other
+func (m Money) Add(other Money) Money {
+	return Money{d: m.d.Add(other.d)}
+}
+
+// Sub returns m - other
+func (m Money) Sub(other Money) Money {
+	return Money{d: m.d.Sub(other.d)}
+}
+
+// Mul returns m * other
+func (m Money) Mul(other Money) Money {
+	return Money{d: m.d.Mul(other.d)}
+}
+
+// MarshalJSON encodes the amount as a JSON string
+func (m Money) MarshalJSON() ([]byte, error) {
+	return json.Marshal(m.d.String())
+}
+
+// UnmarshalJSON decodes the amount from a JSON string or number, empty values decode to zero
+func (m *Money) UnmarshalJSON(data []byte) error {
+	data = bytes.Trim(data, `"`) // Cloudbet sends amounts as strings, but accept bare numbers too
+	if len(data) == 0 || string(data) == "null" {
+		*m = Money{} // Treat missing amounts as zero
+		return nil
+	}
+
+	parsed, err := NewMoney(string(data))
+	if err != nil {
+		return err // Return error if the value is not a decimal
+	}
+	*m = parsed
+	return nil
+}
+
+// MoneyFromFloat returns Money holding the shortest decimal representation of value,
+// use it to convert prices decoded as float64 (e.g. 1.91 stays exactly 1.91)
+func MoneyFromFloat(value float64) Money {
+	return Money{d: decimal.NewFromFloat(value)}
+}
+
+// selectionAmounts holds the amounts of a selection exactly as Cloudbet sent them
+type selectionAmounts struct {
+	price    json.Number // Exact price, empty when the selection was not decoded
+	minStake json.Number // Exact minimum stake
+	maxStake json.Number // Exact maximum stake
+}
+
+// UnmarshalJSON decodes a selection, keeping its price and stake limits exactly as sent next to the
+// float64 fields, see PriceMoney
+func (s *Selections) UnmarshalJSON(data []byte) error {
+	type selections Selections // Alias without methods to avoid recursion
+	aux := struct {
+		*selections
+		Price    json.Number `json:"price"`    // Shadows the float64 fields to read the exact decimals
+		MinStake json.Number `json:"minStake"` // Minimum stake as sent
+		MaxStake json.Number `json:"maxStake"` // Maximum stake as sent
+	}{selections: (*selections)(s)}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+
+	s.exact = selectionAmounts{price: aux.Price, minStake: aux.MinStake, maxStake: aux.MaxStake}
+	var err error
+	if s.Price, err = numberFloat(aux.Price); err != nil {
+		return err
+	}
+	if s.MinStake, err = numberFloat(aux.MinStake); err != nil {
+		return err
+	}
+	s.MaxStake, err = numberFloat(aux.MaxStake)
+	return err
+}
+
+// numberFloat converts a decoded number to float64, a missing number is zero
+func numberFloat(n json.Number) (float64, error) {
+	if n == "" {
+		return 0, nil
+	}
+	return n.Float64()
+}
+
+// exactMoney returns the exact amount when one was decoded, otherwise the float64 field
+func exactMoney(n json.Number, value float64) Money {
+	if n != "" {
+		if m, err := NewMoney(n.String()); err == nil {
+			return m
+		}
+	}
+	return MoneyFromFloat(value)
+}
+
+// PriceMoney returns the price of the selection as exact Money, as Cloudbet sent it. Selections built
+// in code, rather than decoded, fall back to Price
+func (s Selections) PriceMoney() Money {
+	return exactMoney(s.exact.price, s.Price)
+}
+
+// MinStakeMoney returns the minimum stake of the selection as exact Money, see PriceMoney
+func (s Selections) MinStakeMoney() Money {
+	return exactMoney(s.exact.minStake, s.MinStake)
+}
+
+// MaxStakeMoney returns the maximum stake of the selection as exact Money, see PriceMoney
+func (s Selections) MaxStakeMoney() Money {
+	return exactMoney(s.exact.maxStake, s.MaxStake)
+}
+
+// PotentialReturn returns what a winning bet pays back, stake included, e.g. 19.1 for 10 at 1.91
+func PotentialReturn(stake, price Money) Money {
+	return stake.Mul(price)
+}
+
+// PotentialProfit returns what a winning bet earns on top of the stake, e.g. 9.1 for 10 at 1.91
+func PotentialProfit(stake, price Money) Money {
+	return PotentialReturn(stake, price).Sub(stake)
+}
+
+// defaultStakePlaces is the number of decimal places stakes are rounded to, unless the minimum
+// stake of the selection is finer (as for crypto currencies)
+const defaultStakePlaces = 2
+
+// ClampStake rounds a stake down to the precision Cloudbet accepts and caps it at the maximum stake of
+// the selection. The precision is 2 places, or that of the minimum stake when it has more. It returns
+// an error matching ErrStakeTooLow when the rounded stake is below the minimum stake
+func ClampStake(stake Money, sel Selections) (Money, error) {
+	minStake, maxStake := sel.MinStakeMoney(), sel.MaxStakeMoney()
+	places := max(defaultStakePlaces, -minStake.d.Exponent())
+
+	clamped := Money{d: stake.d.RoundDown(places)}
+	if sel.MaxStake > 0 && clamped.Cmp(maxStake) > 0 {
+		clamped = Money{d: maxStake.d.RoundDown(places)} // Never stake more than allowed
+	}
+	if clamped.Cmp(minStake) < 0 {
+		return Money{}, fmt.Errorf("stake %s is below the minimum stake %s: %w", stake, minStake, ErrStakeTooLow)
+	}
+	return clamped, nil
+}
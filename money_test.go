@@ -0,0 +1,118 @@
+package cloudbet
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+// TestMoneyJSON tests that Money round trips through JSON without losing precision
+func TestMoneyJSON(t *testing.T) {
+	payload := PlaceBetPayload{Price: MustMoney("1.10"), Stake: MustMoney("0.3")}
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	var decoded PlaceBetPayload
+	if err := json.Unmarshal(body, &decoded); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if got := decoded.Price.Mul(decoded.Stake).String(); got != "0.33" {
+		t.Fatalf("expected exact return 0.33, got %s", got)
+	}
+}
+
+// TestMoneyUnmarshal tests the accepted JSON encodings of Money
+func TestMoneyUnmarshal(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{`"12.345"`, "12.345"},
+		{`7.5`, "7.5"},
+		{`""`, "0"},
+		{`null`, "0"},
+	}
+	for _, tt := range tests {
+		var m Money
+		if err := json.Unmarshal([]byte(tt.input), &m); err != nil {
+			t.Fatalf("%s: expected no error, got %v", tt.input, err)
+		}
+		if m.String() != tt.want {
+			t.Fatalf("%s: expected %s, got %s", tt.input, tt.want, m.String())
+		}
+	}
+
+	var m Money
+	if err := json.Unmarshal([]byte(`"abc"`), &m); err == nil {
+		t.Fatal("expected an error for a non decimal value")
+	}
+}
+
+// TestPotentialReturn tests that returns and profits are computed exactly
+func TestPotentialReturn(t *testing.T) {
+	tests := []struct {
+		stake, price, wantReturn, wantProfit string
+	}{
+		{"10", "1.91", "19.1", "9.1"},
+		{"0.1", "3.3", "0.33", "0.23"}, // Inexact in float64
+		{"5", "2", "10", "5"},
+	}
+	for _, tt := range tests {
+		stake, price := MustMoney(tt.stake), MustMoney(tt.price)
+		if got := PotentialReturn(stake, price).String(); got != tt.wantReturn {
+			t.Fatalf("PotentialReturn(%s, %s): expected %s, got %s", tt.stake, tt.price, tt.wantReturn, got)
+		}
+		if got := PotentialProfit(stake, price).String(); got != tt.wantProfit {
+			t.Fatalf("PotentialProfit(%s, %s): expected %s, got %s", tt.stake, tt.price, tt.wantProfit, got)
+		}
+	}
+}
+
+// TestClampStake tests that stakes are rounded down and clamped to the selection limits
+func TestClampStake(t *testing.T) {
+	selection := Selections{MinStake: 0.1, MaxStake: 500}
+	tests := []struct {
+		stake, want string
+	}{
+		{"1.23456", "1.23"},
+		{"750", "500"},
+		{"0.109", "0.1"},
+	}
+	for _, tt := range tests {
+		got, err := ClampStake(MustMoney(tt.stake), selection)
+		if err != nil || got.String() != tt.want {
+			t.Fatalf("ClampStake(%s): expected %s, got %s, %v", tt.stake, tt.want, got, err)
+		}
+	}
+
+	if got, err := ClampStake(MustMoney("0.000123456"), Selections{MinStake: 0.0001, MaxStake: 1}); err != nil || got.String() != "0.0001" {
+		t.Fatalf("expected the precision of the minimum stake, got %s, %v", got, err)
+	}
+	if _, err := ClampStake(MustMoney("0.099"), selection); !errors.Is(err, ErrStakeTooLow) {
+		t.Fatalf("expected ErrStakeTooLow, got %v", err)
+	}
+}
+
+// TestSelectionsExactAmounts tests that decoded selections keep their price and stake limits exactly
+func TestSelectionsExactAmounts(t *testing.T) {
+	var selection Selections
+	data := `{"outcome":"home","price":"1.1234567890123456789","minStake":0.00000001,"maxStake":"2500.5","status":"SELECTION_ENABLED"}`
+	if err := json.Unmarshal([]byte(data), &selection); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if selection.Outcome != "home" || selection.Status != SelectionStatusEnabled || selection.Price != 1.1234567890123457 || selection.MaxStake != 2500.5 {
+		t.Fatalf("unexpected selection %+v", selection)
+	}
+	if got := selection.PriceMoney().String(); got != "1.1234567890123456789" {
+		t.Fatalf("expected the exact price, got %s", got)
+	}
+	if got := selection.MinStakeMoney().String(); got != "0.00000001" {
+		t.Fatalf("expected the exact minimum stake, got %s", got)
+	}
+	if got := (Selections{Price: 1.91}).PriceMoney().String(); got != "1.91" {
+		t.Fatalf("expected selections built in code to fall back to Price, got %s", got)
+	}
+}
@@ -0,0 +1,110 @@
+package cloudbet
+
+import (
+	"context"
+	"fmt"
+	"math"
+
+	"github.com/shopspring/decimal"
+)
+
+// americanPricePlaces is the number of decimal places of prices converted from American odds
+const americanPricePlaces = 3
+
+// DecimalToAmerican converts decimal odds to American odds, e.g. 2.5 to +150 and 1.5 to -200.
+// Even money (2.0) converts to +100. Prices of 1 or less are invalid and give 0
+func DecimalToAmerican(price float64) int {
+	switch {
+	case price <= 1:
+		return 0
+	case price >= 2:
+		return int(math.Round((price - 1) * 100)) // Profit on a 100 stake
+	default:
+		return int(math.Round(-100 / (price - 1))) // Stake needed to win 100
+	}
+}
+
+// AmericanToDecimal converts American odds to decimal odds, e.g. +150 to 2.5 and -200 to 1.5.
+// Both +100 and -100 convert to even money (2.0). Odds between -100 and +100 are invalid and give 0
+func AmericanToDecimal(american int) float64 {
+	price, err := americanToMoney(american)
+	if err != nil {
+		return 0
+	}
+	return price.Float64()
+}
+
+// americanToMoney converts American odds to an exact decimal price
+func americanToMoney(american int) (Money, error) {
+	hundred := decimal.NewFromInt(100)
+	switch {
+	case american >= 100:
+		return Money{d: decimal.NewFromInt(int64(american)).Div(hundred).Add(decimal.NewFromInt(1))}, nil
+	case american <= -100:
+		return Money{d: hundred.Div(decimal.NewFromInt(int64(-american))).Add(decimal.NewFromInt(1))}, nil
+	default:
+		return Money{}, fmt.Errorf("invalid American odds %d, expected at least +100 or at most -100", american)
+	}
+}
+
+// DecimalToFractional converts decimal odds to reduced fractional odds, e.g. 2.5 to 3/2 and 1.91 to 91/100.
+// Even money (2.0) converts to 1/1. Prices of 1 or less are invalid and give 0/0
+func DecimalToFractional(price float64) (num, den int) {
+	if price <= 1 {
+		return 0, 0
+	}
+
+	profit := decimal.NewFromFloat(price).Sub(decimal.NewFromInt(1)) // Shortest decimal form, so 1.91 stays 1.91
+	den = 1
+	for exp := profit.Exponent(); exp < 0; exp++ {
+		den *= 10
+	}
+	num = int(profit.Mul(decimal.NewFromInt(int64(den))).IntPart())
+
+	divisor := gcd(num, den)
+	return num / divisor, den / divisor
+}
+
+// gcd returns the greatest common divisor of two positive integers
+func gcd(a, b int) int {
+	for b != 0 {
+		a, b = b, a%b
+	}
+	return a
+}
+
+// PlaceBetAmerican places a bet priced in American odds. The odds are converted to a decimal price
+// rounded down to 3 places, so a repeating price such as -110 never ends up above the market price
+func (c *APIClient) PlaceBetAmerican(ctx context.Context, payload PlaceBetPayload, american int) (*PlaceBetResponse, error) {
+	price, err := americanToMoney(american)
+	if err != nil {
+		return nil, err // Return error if the odds cannot be converted
+	}
+	payload.Price = Money{d: price.d.RoundDown(americanPricePlaces)}
+	return c.PlaceBet(ctx, payload)
+}
+
+// ImpliedProbability returns the probability implied by decimal odds, e.g. 0.5 for 2.0.
+// Prices of 0 or less are invalid and give 0
+func ImpliedProbability(price float64) float64 {
+	if price <= 0 {
+		return 0
+	}
+	return 1 / price
+}
+
+// MarketOverround returns the bookmaker margin of a market, the sum of the implied probabilities of
+// its selections minus 1 (e.g. 0.05 for a 105% book). Suspended and unpriced selections are excluded
+func MarketOverround(selections []Selections) float64 {
+	var total float64
+	for _, selection := range selections {
+		if !selection.IsTradable() {
+			continue // Only count selections that can be bet on
+		}
+		total += ImpliedProbability(selection.Price)
+	}
+	if total == 0 {
+		return 0 // No tradable selections, there is no book
+	}
+	return total - 1
+}
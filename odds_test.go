@@ -0,0 +1,84 @@
+package cloudbet
+
+import (
+	"context"
+	"math"
+	"testing"
+)
+
+// TestOddsConversions tests conversions between decimal, American and fractional odds
+func TestOddsConversions(t *testing.T) {
+	tests := []struct {
+		price    float64
+		american int
+		num, den int
+	}{
+		{2.0, 100, 1, 1},
+		{2.5, 150, 3, 2},
+		{1.5, -200, 1, 2},
+		{1.91, -110, 91, 100},
+		{11, 1000, 10, 1},
+	}
+	for _, tt := range tests {
+		if american := DecimalToAmerican(tt.price); american != tt.american {
+			t.Fatalf("DecimalToAmerican(%v): expected %d, got %d", tt.price, tt.american, american)
+		}
+		if num, den := DecimalToFractional(tt.price); num != tt.num || den != tt.den {
+			t.Fatalf("DecimalToFractional(%v): expected %d/%d, got %d/%d", tt.price, tt.num, tt.den, num, den)
+		}
+	}
+
+	if price := AmericanToDecimal(100); price != 2 {
+		t.Fatalf("expected +100 to be 2.0, got %v", price)
+	}
+	if price := AmericanToDecimal(-100); price != 2 {
+		t.Fatalf("expected -100 to be 2.0, got %v", price)
+	}
+	if price := AmericanToDecimal(-200); price != 1.5 {
+		t.Fatalf("expected -200 to be 1.5, got %v", price)
+	}
+	if price := AmericanToDecimal(50); price != 0 {
+		t.Fatalf("expected +50 to be invalid, got %v", price)
+	}
+	if american := DecimalToAmerican(1); american != 0 {
+		t.Fatalf("expected 1.0 to be invalid, got %d", american)
+	}
+}
+
+// TestPlaceBetAmerican tests that American odds are converted to a decimal price before placing
+func TestPlaceBetAmerican(t *testing.T) {
+	client, closeServer := newTestClient(nil)
+	defer closeServer()
+
+	bet, err := client.PlaceBetAmerican(context.Background(), PlaceBetPayload{UUID: "ref-1", Stake: MustMoney("10")}, -110)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if bet.Price.String() != "1.909" {
+		t.Fatalf("expected price 1.909, got %s", bet.Price)
+	}
+
+	if _, err := client.PlaceBetAmerican(context.Background(), PlaceBetPayload{UUID: "ref-2"}, 0); err == nil {
+		t.Fatalf("expected invalid odds to be rejected")
+	}
+}
+
+// TestMarketOverround tests that the margin only counts tradable selections
+func TestMarketOverround(t *testing.T) {
+	if p := ImpliedProbability(2.5); p != 0.4 {
+		t.Fatalf("expected 0.4, got %v", p)
+	}
+
+	selections := []Selections{
+		{Outcome: "home", Price: 1.9, Status: "SELECTION_ENABLED"},
+		{Outcome: "away", Price: 1.9, Status: "SELECTION_ENABLED"},
+		{Outcome: "draw", Price: 3.2, Status: "SELECTION_SUSPENDED"},
+		{Outcome: "other", Price: 0, Status: "SELECTION_ENABLED"},
+	}
+	if margin := MarketOverround(selections); math.Abs(margin-(2/1.9-1)) > 1e-9 {
+		t.Fatalf("expected a margin of %v, got %v", 2/1.9-1, margin)
+	}
+	if margin := MarketOverround(selections[2:3]); margin != 0 {
+		t.Fatalf("expected no margin without tradable selections, got %v", margin)
+	}
+}
@@ -0,0 +1,133 @@
+package cloudbet
+
+import (
+	"net/http"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+// Version is the version of this library, reported in the default User-Agent
+const Version = "1.0.0"
+
+const (
+	DefaultBaseURL   = "https://sports-api.cloudbet.com" // Production base URL for the Cloudbet API
+	DefaultTimeout   = 10 * time.Second                  // Default timeout for the HTTP client
+	DefaultUserAgent = "CloudbetClient-Go/" + Version    // Default User-Agent sent with every request
+
+	DefaultMaxResponseBytes = 32 << 20 // Default maximum size of a response body, 32 MiB
+)
+
+// Option configures an APIClient, options are applied in the order they are passed to NewAPIClient
+type Option func(*APIClient)
+
+// WithBaseURL points the client at a different API endpoint, for example a staging environment
+func WithBaseURL(baseURL string) Option {
+	return func(c *APIClient) {
+		c.BaseURL = baseURL // Override the production base URL
+	}
+}
+
+// WithTimeout sets the timeout of the HTTP client used by the client. It applies to calls
+// whose context has no deadline, a context deadline takes precedence over it so a single
+// slow call can be given more (or less) time with context.WithTimeout
+func WithTimeout(timeout time.Duration) Option {
+	return func(c *APIClient) {
+		client := *c.Client // Copy the client so a caller supplied one is never mutated
+		client.Timeout = timeout
+		c.Client = &client
+	}
+}
+
+// WithHTTPClient replaces the HTTP client used to send requests, including its transport and
+// timeout, use it to route through a proxy or pin certificates with a custom http.Transport.
+// Options apply in order: a WithTimeout passed after it overrides the timeout of the given client
+// on a copy, one passed before it is discarded. The given client is never modified
+func WithHTTPClient(client *http.Client) Option {
+	return func(c *APIClient) {
+		if client != nil {
+			c.Client = client            // Use the provided client as is
+			c.insecureSkipVerify = false // The transport of an earlier WithInsecureSkipVerify is replaced
+		}
+	}
+}
+
+// WithRateLimit makes every request wait for a token from a limiter allowing rps requests
+// per second with the given burst. The limiter is shared by all methods of the client
+func WithRateLimit(rps float64, burst int) Option {
+	return func(c *APIClient) {
+		c.limiter = rate.NewLimiter(rate.Limit(rps), burst)
+	}
+}
+
+// WithUserAgent overrides the User-Agent header sent with every request
+func WithUserAgent(userAgent string) Option {
+	return func(c *APIClient) {
+		c.userAgent = userAgent
+	}
+}
+
+// WithHeader adds a header sent with every request, e.g. a gateway token. It cannot replace the
+// headers the client sets itself (X-API-Key, Accept, Accept-Encoding, User-Agent and Content-Type),
+// use WithUserAgent for the User-Agent
+func WithHeader(key, value string) Option {
+	return func(c *APIClient) {
+		if c.headers == nil {
+			c.headers = make(http.Header)
+		}
+		c.headers.Add(key, value)
+	}
+}
+
+// WithLocale asks for names (sports, competitions, teams, events) in the given language, e.g. "de",
+// through the Accept-Language header of every request. Names are returned in the default language when
+// it is unset, or when Cloudbet has no translation
+func WithLocale(locale string) Option {
+	return func(c *APIClient) {
+		c.locale = locale
+	}
+}
+
+// WithMaxResponseBytes bounds the size of a response body, once decompressed, so a huge or endless
+// response cannot exhaust memory. Larger responses fail with an error matching ErrResponseTooLarge.
+// The default is DefaultMaxResponseBytes, zero or a negative value removes the limit. The odds stream
+// is not limited
+func WithMaxResponseBytes(n int64) Option {
+	return func(c *APIClient) {
+		c.maxResponseBytes = n
+	}
+}
+
+// WithDefaultCurrency sets the currency used when a bet payload or balance request does not name one.
+// Currencies given explicitly always take precedence
+func WithDefaultCurrency(currency string) Option {
+	return func(c *APIClient) {
+		c.defaultCurrency = currency
+	}
+}
+
+// WithAutoReferenceID makes PlaceBet generate a random UUID reference ID for payloads without one.
+// The generated ID is returned on the response and on a BetSubmissionError, even after a timeout
+func WithAutoReferenceID() Option {
+	return func(c *APIClient) {
+		c.autoReferenceID = true
+	}
+}
+
+// WithPlayMoneyOnly makes PlaceBet and the other bet placing methods reject, before anything is sent,
+// bets whose currency is not play money (e.g. EUR instead of PLAY_EUR), returning an error matching
+// ErrRealMoney. Use it as a safety rail in staging. Without it, or after WithRealMoney, any currency
+// is accepted as before
+func WithPlayMoneyOnly() Option {
+	return func(c *APIClient) {
+		c.playMoneyOnly = true
+	}
+}
+
+// WithRealMoney explicitly allows bets in real currencies, overriding an earlier WithPlayMoneyOnly,
+// e.g. one set by shared staging options. Real currencies are also allowed when neither option is given
+func WithRealMoney() Option {
+	return func(c *APIClient) {
+		c.playMoneyOnly = false
+	}
+}
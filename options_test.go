@@ -0,0 +1,284 @@
+package cloudbet
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+)
+
+// TestNewAPIClientDefaults tests that a client without options keeps the production defaults
+func TestNewAPIClientDefaults(t *testing.T) {
+	client := NewAPIClient(apikey)
+
+	if client.BaseURL != DefaultBaseURL {
+		t.Fatalf("expected base URL %s, got %s", DefaultBaseURL, client.BaseURL)
+	}
+	if client.Client.Timeout != DefaultTimeout {
+		t.Fatalf("expected timeout %v, got %v", DefaultTimeout, client.Client.Timeout)
+	}
+}
+
+// TestNewAPIClientOptions tests that options override the defaults without mutating caller values
+func TestNewAPIClientOptions(t *testing.T) {
+	httpClient := &http.Client{Timeout: time.Minute}
+	client := NewAPIClient(apikey,
+		WithBaseURL("https://staging.example.com"),
+		WithHTTPClient(httpClient),
+		WithTimeout(3*time.Second),
+	)
+
+	if client.BaseURL != "https://staging.example.com" {
+		t.Fatalf("expected staging base URL, got %s", client.BaseURL)
+	}
+	if client.Client.Timeout != 3*time.Second {
+		t.Fatalf("expected timeout 3s, got %v", client.Client.Timeout)
+	}
+	if httpClient.Timeout != time.Minute {
+		t.Fatalf("expected caller client to be untouched, got timeout %v", httpClient.Timeout)
+	}
+}
+
+// TestWithHTTPClientProxy tests that a proxy configured on a custom transport is used for every request
+func TestWithHTTPClientProxy(t *testing.T) {
+	var proxied []string
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		proxied = append(proxied, r.URL.Host+r.URL.Path) // A forward proxy sees the absolute target URL
+		w.Write([]byte(`{"amount":"10"}`))
+	}))
+	defer proxy.Close()
+
+	proxyURL, err := url.Parse(proxy.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	httpClient := &http.Client{Transport: &http.Transport{Proxy: http.ProxyURL(proxyURL)}}
+	client := NewAPIClient(apikey,
+		WithTimeout(time.Second), // Discarded, the client below replaces it
+		WithBaseURL("http://sports-api.cloudbet.invalid"),
+		WithHTTPClient(httpClient),
+	)
+
+	if client.Client != httpClient || client.Client.Timeout != 0 {
+		t.Fatalf("expected the custom client to replace the internal one, got %+v", client.Client)
+	}
+	if _, err := client.AccountBalance(context.Background(), "PLAY_EUR"); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(proxied) != 1 || proxied[0] != "sports-api.cloudbet.invalid/pub/v1/account/currencies/PLAY_EUR/balance" {
+		t.Fatalf("expected the request to go through the proxy, got %v", proxied)
+	}
+}
+
+// TestWithRateLimit tests that requests wait for the shared limiter
+func TestWithRateLimit(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	client := NewAPIClient(apikey, WithBaseURL(server.URL), WithRateLimit(20, 1))
+
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		if _, err := client.GetEvent(context.Background(), "1"); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed < 90*time.Millisecond {
+		t.Fatalf("expected requests to be throttled, took %v", elapsed)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := client.AccountBalance(ctx, "PLAY_EUR"); !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got %v", err)
+	}
+}
+
+// TestWithUserAgent tests the default and overridden User-Agent
+func TestWithUserAgent(t *testing.T) {
+	var userAgent string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		userAgent = r.Header.Get("User-Agent")
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	client := NewAPIClient(apikey, WithBaseURL(server.URL))
+	if _, err := client.GetEvent(context.Background(), "1"); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if userAgent != "CloudbetClient-Go/"+Version {
+		t.Fatalf("expected default User-Agent, got %q", userAgent)
+	}
+
+	client = NewAPIClient(apikey, WithBaseURL(server.URL), WithUserAgent("my-bot/2"))
+	if _, err := client.PlaceBet(context.Background(), PlaceBetPayload{}); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if userAgent != "my-bot/2" {
+		t.Fatalf("expected custom User-Agent, got %q", userAgent)
+	}
+}
+
+// TestWithHeader tests that custom headers are sent without replacing the mandatory ones
+func TestWithHeader(t *testing.T) {
+	var header http.Header
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		header = r.Header.Clone()
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	client := NewAPIClient(apikey, WithBaseURL(server.URL),
+		WithHeader("X-Gateway-Token", "gw-1"),
+		WithHeader("x-region", "eu"),
+		WithHeader("x-region", "us"),
+		WithHeader("X-API-Key", "hijacked"),
+		WithHeader("Accept", "text/html"),
+	)
+	if _, err := client.GetEvent(context.Background(), "1"); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if header.Get("X-Gateway-Token") != "gw-1" || len(header.Values("X-Region")) != 2 {
+		t.Fatalf("expected the custom headers, got %v", header)
+	}
+	if header.Get("X-API-Key") != apikey || header.Get("Accept") != "application/json" {
+		t.Fatalf("expected the mandatory headers to win, got %v", header)
+	}
+}
+
+// TestWithLocale tests that the locale is only sent when set
+func TestWithLocale(t *testing.T) {
+	var languages []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		languages = append(languages, r.Header.Get("Accept-Language"))
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	NewAPIClient(apikey, WithBaseURL(server.URL), WithLocale("de")).GetEvent(context.Background(), "1")
+	NewAPIClient(apikey, WithBaseURL(server.URL)).GetEvent(context.Background(), "1")
+
+	if len(languages) != 2 || languages[0] != "de" || languages[1] != "" {
+		t.Fatalf("expected the locale on the first request only, got %q", languages)
+	}
+}
+
+// TestWithDefaultCurrency tests that the default currency only fills empty values
+func TestWithDefaultCurrency(t *testing.T) {
+	var currencies []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "POST" {
+			var payload PlaceBetPayload
+			json.NewDecoder(r.Body).Decode(&payload)
+			currencies = append(currencies, payload.Currency)
+		} else {
+			currencies = append(currencies, r.URL.Path)
+		}
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	client := NewAPIClient(apikey, WithBaseURL(server.URL), WithDefaultCurrency("PLAY_EUR"))
+	ctx := context.Background()
+
+	client.PlaceBet(ctx, PlaceBetPayload{})
+	client.PlaceBet(ctx, PlaceBetPayload{Currency: "BTC"})
+	client.AccountBalance(ctx, "")
+
+	want := []string{"PLAY_EUR", "BTC", "/pub/v1/account/currencies/PLAY_EUR/balance"}
+	if len(currencies) != len(want) {
+		t.Fatalf("expected %v, got %v", want, currencies)
+	}
+	for i := range want {
+		if currencies[i] != want[i] {
+			t.Fatalf("expected %v, got %v", want, currencies)
+		}
+	}
+}
+
+// TestContextDeadlineOverridesTimeout tests that a context deadline replaces the client timeout
+func TestContextDeadlineOverridesTimeout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(150 * time.Millisecond) // Slower than the client timeout
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	client := NewAPIClient(apikey, WithBaseURL(server.URL), WithTimeout(50*time.Millisecond))
+
+	if _, err := client.GetEvent(context.Background(), "1"); err == nil {
+		t.Fatal("expected the client timeout to apply without a context deadline")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if _, err := client.GetEvent(ctx, "1"); err != nil {
+		t.Fatalf("expected the context deadline to take precedence, got %v", err)
+	}
+}
+
+// TestWithAPIVersions tests that each resource can be moved to another API version on its own
+func TestWithAPIVersions(t *testing.T) {
+	var paths []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	client := NewAPIClient(apikey, WithBaseURL(server.URL), WithOddsAPIVersion("v3"), WithAccountAPIVersion("v2"))
+	ctx := context.Background()
+
+	client.GetEvent(ctx, "1")
+	client.AccountBalance(ctx, "PLAY_EUR")
+	client.GetBetStatus(ctx, "ref-1")
+
+	want := []string{"/pub/v3/odds/events/1", "/pub/v2/account/currencies/PLAY_EUR/balance", "/pub/v3/bets/ref-1/status"}
+	if len(paths) != len(want) {
+		t.Fatalf("expected paths %v, got %v", want, paths)
+	}
+	for i := range want {
+		if paths[i] != want[i] {
+			t.Fatalf("expected paths %v, got %v", want, paths)
+		}
+	}
+}
+
+// TestWithPlayMoneyOnly tests that real currency bets are rejected before being sent unless real money is allowed
+func TestWithPlayMoneyOnly(t *testing.T) {
+	var placed int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		placed++
+		w.Write([]byte(`{"referenceId":"ref-1","status":"ACCEPTED"}`))
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	payload := PlaceBetPayload{EventId: "1", MarketURL: "soccer.match_odds/home", Price: MustMoney("1.9"), Stake: MustMoney("1"), UUID: "ref-1"}
+
+	client := NewAPIClient(apikey, WithBaseURL(server.URL), WithPlayMoneyOnly(), WithDefaultCurrency("EUR"))
+	if _, err := client.PlaceBet(ctx, payload); !errors.Is(err, ErrRealMoney) {
+		t.Fatalf("expected ErrRealMoney, got %v", err)
+	}
+	payload.Currency = "PLAY_EUR"
+	if _, err := client.PlaceBet(ctx, payload); err != nil {
+		t.Fatalf("expected the play money bet to be placed, got %v", err)
+	}
+	if placed != 1 {
+		t.Fatalf("expected only the play money bet to be sent, got %d requests", placed)
+	}
+
+	payload.Currency = "EUR"
+	client = NewAPIClient(apikey, WithBaseURL(server.URL), WithPlayMoneyOnly(), WithRealMoney())
+	if _, err := client.PlaceBet(ctx, payload); err != nil {
+		t.Fatalf("expected real money to be allowed, got %v", err)
+	}
+}
@@ -0,0 +1,68 @@
+package cloudbet
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// parseParams splits a selection params string such as "handicap=-0.5&period=ft" into its pairs.
+// An empty string gives an empty map
+func parseParams(params string) (map[string]string, error) {
+	parsed := make(map[string]string)
+	if params == "" {
+		return parsed, nil
+	}
+	for _, pair := range strings.Split(params, "&") {
+		key, value, ok := strings.Cut(pair, "=")
+		if !ok || key == "" {
+			return nil, fmt.Errorf("invalid params %q: expected key=value, got %q", params, pair)
+		}
+		if _, duplicate := parsed[key]; duplicate {
+			return nil, fmt.Errorf("invalid params %q: %s is repeated", params, key)
+		}
+		parsed[key] = value
+	}
+	return parsed, nil
+}
+
+// paramFloat returns a numeric param, e.g. the line of "total=2.5". ok is false when the param is missing
+func paramFloat(params, key string) (value float64, ok bool, err error) {
+	parsed, err := parseParams(params)
+	if err != nil {
+		return 0, false, err
+	}
+	raw, ok := parsed[key]
+	if !ok {
+		return 0, false, nil
+	}
+	value, err = strconv.ParseFloat(raw, 64)
+	if err != nil {
+		return 0, true, fmt.Errorf("invalid params %q: %s is not a number", params, key)
+	}
+	return value, true, nil
+}
+
+// ParsedParams splits the params of the selection into their keys and values, e.g. {"total": "2.5"}.
+// A selection without params gives an empty map
+func (s Selections) ParsedParams() (map[string]string, error) {
+	return parseParams(s.Params)
+}
+
+// ParamFloat returns a numeric param of the selection, such as "total" or "handicap", to compare lines.
+// ok is false when the selection has no such param
+func (s Selections) ParamFloat(key string) (value float64, ok bool, err error) {
+	return paramFloat(s.Params, key)
+}
+
+// ParsedParams splits the params of the opinion into their keys and values, e.g. {"total": "2.5"}.
+// An opinion without params gives an empty map
+func (o Opinion) ParsedParams() (map[string]string, error) {
+	return parseParams(o.Params)
+}
+
+// ParamFloat returns a numeric param of the opinion, such as "total" or "handicap".
+// ok is false when the opinion has no such param
+func (o Opinion) ParamFloat(key string) (value float64, ok bool, err error) {
+	return paramFloat(o.Params, key)
+}
@@ -0,0 +1,33 @@
+package cloudbet
+
+import "testing"
+
+// TestParsedParams tests splitting params into pairs and numbers
+func TestParsedParams(t *testing.T) {
+	selection := Selections{Params: "handicap=-0.75&period=ft"}
+	params, err := selection.ParsedParams()
+	if err != nil || len(params) != 2 || params["handicap"] != "-0.75" || params["period"] != "ft" {
+		t.Fatalf("unexpected params %v, %v", params, err)
+	}
+	if handicap, ok, err := selection.ParamFloat("handicap"); err != nil || !ok || handicap != -0.75 {
+		t.Fatalf("unexpected handicap %v, %v, %v", handicap, ok, err)
+	}
+	if _, ok, err := selection.ParamFloat("total"); err != nil || ok {
+		t.Fatalf("expected a missing param, got %v, %v", ok, err)
+	}
+	if _, _, err := selection.ParamFloat("period"); err == nil {
+		t.Fatalf("expected an error for a non numeric param")
+	}
+
+	if params, err := (Opinion{}).ParsedParams(); err != nil || params == nil || len(params) != 0 {
+		t.Fatalf("expected empty params, got %v, %v", params, err)
+	}
+	if total, ok, err := (Opinion{Params: "total=2.5"}).ParamFloat("total"); err != nil || !ok || total != 2.5 {
+		t.Fatalf("unexpected total %v, %v, %v", total, ok, err)
+	}
+	for _, invalid := range []string{"total", "=2.5", "total=2.5&total=3.5"} {
+		if _, err := (Selections{Params: invalid}).ParsedParams(); err == nil {
+			t.Fatalf("expected an error for params %q", invalid)
+		}
+	}
+}
@@ -0,0 +1,92 @@
+package cloudbet
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"net/http"
+	"time"
+)
+
+// Default polling configuration of WaitForSettlement
+const (
+	DefaultPollInterval    = 2 * time.Second  // Delay before the second status check
+	DefaultPollMaxInterval = 30 * time.Second // Upper bound of the delay between status checks
+	DefaultPollMultiplier  = 1.5              // Growth of the delay after every status check
+)
+
+// PollOpts configures WaitForSettlement, zero values use the defaults
+type PollOpts struct {
+	Interval    time.Duration // Delay before the second status check
+	MaxInterval time.Duration // Upper bound of the delay between status checks
+	Multiplier  float64       // Growth of the delay after every check, 1 for a constant interval
+}
+
+// withDefaults returns the options with zero values replaced by the defaults
+func (o PollOpts) withDefaults() PollOpts {
+	if o.Interval <= 0 {
+		o.Interval = DefaultPollInterval
+	}
+	if o.MaxInterval <= 0 {
+		o.MaxInterval = max(DefaultPollMaxInterval, o.Interval)
+	}
+	if o.Multiplier < 1 {
+		o.Multiplier = DefaultPollMultiplier
+	}
+	return o
+}
+
+// WaitForSettlement polls GetBetStatus until the bet is settled or rejected, or ctx is done, and
+// returns its final state. Checks start right away and are spaced by a jittered delay growing by
+// Multiplier up to MaxInterval. Rate limited checks wait at least the Retry-After delay, and network
+// errors and server errors are polled through. Any other error, such as an unknown reference ID,
+// is returned. A status this library does not know stops polling with the bet and an error matching
+// ErrUnknownBetStatus
+func (c *APIClient) WaitForSettlement(ctx context.Context, referenceId string, opts PollOpts) (*PlaceBetResponse, error) {
+	opts = opts.withDefaults()
+	for interval := opts.Interval; ; interval = min(time.Duration(float64(interval)*opts.Multiplier), opts.MaxInterval) {
+		bet, err := c.GetBetStatus(ctx, referenceId)
+		delay := pollJitter(interval)
+		switch {
+		case err == nil && (bet.Status.IsSettled() || bet.Status.IsRejected()):
+			return bet, nil // The status is final
+		case err == nil && bet.Status == BetStatusUnknown:
+			return bet, fmt.Errorf("bet %s: %w", referenceId, ErrUnknownBetStatus) // Return error rather than poll for a status that may never change
+		case err != nil && ctx.Err() != nil:
+			return nil, err // Return error if the caller gave up
+		case err != nil:
+			var rateLimitErr *RateLimitError
+			if errors.As(err, &rateLimitErr) {
+				delay = max(delay, rateLimitErr.RetryAfter) // Wait at least as long as asked
+			} else if !transientError(err) {
+				return nil, err // Return error if polling again cannot help
+			}
+			c.logf("cloudbet: checking the status of bet %s failed: %v", referenceId, err)
+		}
+		if err := sleepContext(ctx, delay); err != nil {
+			return nil, err // Stop polling once the context is done
+		}
+	}
+}
+
+// transientError reports whether a request failed in a way that may not happen again
+func transientError(err error) bool {
+	var (
+		networkErr *NetworkError
+		apiErr     *APIError
+	)
+	if errors.As(err, &networkErr) {
+		return true
+	}
+	return errors.As(err, &apiErr) && apiErr.StatusCode >= http.StatusInternalServerError
+}
+
+// pollJitter spreads a polling delay between 75% and 125% of its value, so clients polling
+// together drift apart
+func pollJitter(d time.Duration) time.Duration {
+	if d <= 0 {
+		return 0
+	}
+	return d*3/4 + rand.N(d/2+1)
+}
@@ -0,0 +1,76 @@
+package cloudbet
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// TestWaitForSettlement tests that the status is polled through transient failures until it is final
+func TestWaitForSettlement(t *testing.T) {
+	responses := []func(w http.ResponseWriter){
+		func(w http.ResponseWriter) { w.Write([]byte(`{"referenceId":"ref-1","status":"PENDING_ACCEPTANCE"}`)) },
+		func(w http.ResponseWriter) { w.WriteHeader(http.StatusBadGateway) },
+		func(w http.ResponseWriter) {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+		},
+		func(w http.ResponseWriter) { w.Write([]byte(`{"referenceId":"ref-1","status":"ACCEPTED"}`)) },
+		func(w http.ResponseWriter) {
+			w.Write([]byte(`{"referenceId":"ref-1","status":"WIN","returnAmount":"2.5"}`))
+		},
+	}
+	var calls int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/pub/v3/bets/ref-1/status" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		calls++
+		responses[min(calls, len(responses))-1](w)
+	}))
+	defer server.Close()
+
+	client := NewAPIClient(apikey, WithBaseURL(server.URL))
+	opts := PollOpts{Interval: time.Millisecond, MaxInterval: 2 * time.Millisecond}
+
+	bet, err := client.WaitForSettlement(context.Background(), "ref-1", opts)
+	if err != nil || bet.Status != BetStatusWin || calls != len(responses) {
+		t.Fatalf("expected the settled bet after %d checks, got %+v, %v after %d", len(responses), bet, err, calls)
+	}
+
+	var apiErr *APIError
+	if _, err := client.WaitForSettlement(context.Background(), "unknown", opts); !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusNotFound {
+		t.Fatalf("expected the unknown bet to fail, got %v", err)
+	}
+
+	calls = 0
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	responses = responses[:1]
+	if _, err := client.WaitForSettlement(ctx, "ref-1", opts); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected the context error for an open bet, got %v", err)
+	}
+}
+
+// TestWaitForSettlementFinalStatuses tests that every rejection code ends polling and unknown statuses fail
+func TestWaitForSettlementFinalStatuses(t *testing.T) {
+	opts := PollOpts{Interval: time.Millisecond, MaxInterval: 2 * time.Millisecond}
+	for status, wantErr := range map[string]error{"STAKE_BELOW_MIN_BET": nil, "MARKET_CLOSED": nil, "SOMETHING_NEW": ErrUnknownBetStatus} {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte(`{"referenceId":"ref-1","status":"` + status + `"}`))
+		}))
+		client := NewAPIClient(apikey, WithBaseURL(server.URL))
+
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		bet, err := client.WaitForSettlement(ctx, "ref-1", opts)
+		cancel()
+		server.Close()
+		if bet == nil || !errors.Is(err, wantErr) || (wantErr == nil) != bet.Status.IsRejected() {
+			t.Fatalf("%s: expected the bet and %v, got %+v, %v", status, wantErr, bet, err)
+		}
+	}
+}
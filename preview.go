@@ -0,0 +1,80 @@
+package cloudbet
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// BetPreview describes the outcome of a bet before it is placed
+type BetPreview struct {
+	RequestedPrice  Money           // Price given in the payload
+	Price           Money           // Current price of the selection
+	Stake           Money           // Stake given in the payload
+	PotentialReturn Money           // Stake multiplied by the current price
+	PriceChanged    bool            // Whether the current price differs from the requested one
+	MinStake        Money           // Minimum stake accepted on the selection
+	MaxStake        Money           // Maximum stake accepted on the selection
+	Status          SelectionStatus // Status of the selection
+}
+
+// PreviewBet validates a bet and computes its potential return from the live price without
+// placing it. Cloudbet has no quote endpoint, so the price is read from the event markets
+func (c *APIClient) PreviewBet(ctx context.Context, payload PlaceBetPayload) (*BetPreview, error) {
+	payload = c.withDefaults(payload) // Validate the payload as it would be sent
+	if err := validatePayload(payload); err != nil {
+		return nil, fmt.Errorf("invalid bet: %w", err) // Return error if the payload is incomplete
+	}
+	marketKey, outcome, params, err := parseMarketURL(payload.MarketURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid bet: %w", err)
+	}
+
+	event, err := c.GetEventJSON(ctx, payload.EventId) // Fetch the live prices of the event
+	if err != nil {
+		return nil, err
+	}
+	selection, err := event.FindSelection(marketKey, outcome, params)
+	if err != nil {
+		return nil, err // Return error if the market or selection does not exist
+	}
+
+	preview := &BetPreview{
+		RequestedPrice: payload.Price,
+		Price:          selection.PriceMoney(),
+		Stake:          payload.Stake,
+		MinStake:       selection.MinStakeMoney(),
+		MaxStake:       selection.MaxStakeMoney(),
+		Status:         selection.Status,
+	}
+	preview.PotentialReturn = preview.Stake.Mul(preview.Price)
+	preview.PriceChanged = !payload.Price.IsZero() && payload.Price.Cmp(preview.Price) != 0
+
+	switch {
+	case selection.Status != SelectionStatusEnabled:
+		return preview, fmt.Errorf("selection %s is %s: %w", payload.MarketURL, selection.Status, ErrMarketSuspended)
+	case preview.Stake.Cmp(preview.MinStake) < 0:
+		return preview, fmt.Errorf("stake %s below minimum %s: %w", preview.Stake, preview.MinStake, ErrStakeTooLow)
+	case !preview.MaxStake.IsZero() && preview.Stake.Cmp(preview.MaxStake) > 0:
+		return preview, fmt.Errorf("stake %s above maximum %s", preview.Stake, preview.MaxStake)
+	}
+	return preview, nil
+}
+
+// validatePayload checks that the fields every bet needs are set
+func validatePayload(payload PlaceBetPayload) error {
+	var errs []error
+	if payload.EventId == "" {
+		errs = append(errs, errors.New("event ID is empty"))
+	}
+	if payload.MarketURL == "" {
+		errs = append(errs, errors.New("market URL is empty"))
+	}
+	if payload.Currency == "" {
+		errs = append(errs, errors.New("currency is empty"))
+	}
+	if payload.Stake.Cmp(Money{}) <= 0 {
+		errs = append(errs, errors.New("stake must be positive"))
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,51 @@
+package cloudbet
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+// TestPreviewBet tests that the return is computed from the live price
+func TestPreviewBet(t *testing.T) {
+	client, closeServer := newTestClient(nil)
+	defer closeServer()
+
+	preview, err := client.PreviewBet(context.Background(), PlaceBetPayload{
+		EventId:   "24055338",
+		MarketURL: "soccer.total_goals/over?total=2.5",
+		Price:     MustMoney("1.75"),
+		Stake:     MustMoney("10"),
+		Currency:  "PLAY_EUR",
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if preview.Price.String() != "1.8" || preview.PotentialReturn.String() != "18" || !preview.PriceChanged {
+		t.Fatalf("unexpected preview %+v", preview)
+	}
+}
+
+// TestPreviewBetRejections tests the validation of previews
+func TestPreviewBetRejections(t *testing.T) {
+	client, closeServer := newTestClient(nil)
+	defer closeServer()
+	ctx := context.Background()
+	payload := PlaceBetPayload{EventId: "24055338", Stake: MustMoney("1"), Currency: "PLAY_EUR"}
+
+	payload.MarketURL = "soccer.match_odds/away" // Suspended in the canned event
+	if _, err := client.PreviewBet(ctx, payload); !errors.Is(err, ErrMarketSuspended) {
+		t.Fatalf("expected ErrMarketSuspended, got %v", err)
+	}
+	payload.MarketURL = "soccer.corners/over"
+	if _, err := client.PreviewBet(ctx, payload); !errors.Is(err, ErrSelectionNotFound) {
+		t.Fatalf("expected ErrSelectionNotFound, got %v", err)
+	}
+	payload.MarketURL, payload.Stake = "soccer.match_odds/home", MustMoney("0.01")
+	if _, err := client.PreviewBet(ctx, payload); !errors.Is(err, ErrStakeTooLow) {
+		t.Fatalf("expected ErrStakeTooLow, got %v", err)
+	}
+	if _, err := client.PreviewBet(ctx, PlaceBetPayload{}); err == nil {
+		t.Fatal("expected a validation error for an empty payload")
+	}
+}
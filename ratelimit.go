@@ -0,0 +1,98 @@
+package cloudbet
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RateLimitError is returned when Cloudbet rejects a request with 429 Too Many Requests
+type RateLimitError struct {
+	RetryAfter time.Duration // Delay requested by the Retry-After header, zero if absent
+	Err        *APIError     // Underlying API error
+}
+
+// Error implements the error interface
+func (e *RateLimitError) Error() string {
+	return fmt.Sprintf("%v (retry after %v)", e.Err, e.RetryAfter)
+}
+
+// Unwrap returns the underlying API error
+func (e *RateLimitError) Unwrap() error {
+	return e.Err
+}
+
+// ResponseMetadata holds the rate limit information reported on a response
+type ResponseMetadata struct {
+	StatusCode         int           // HTTP status code of the response
+	RateLimitLimit     int           // Value of X-RateLimit-Limit, -1 if not reported
+	RateLimitRemaining int           // Value of X-RateLimit-Remaining, -1 if not reported
+	RateLimitReset     time.Time     // Time given by X-RateLimit-Reset, zero if not reported
+	RetryAfter         time.Duration // Delay given by Retry-After, zero if not reported
+}
+
+// LastResponse returns the metadata of the most recent response received by the client
+func (c *APIClient) LastResponse() ResponseMetadata {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.lastResponse
+}
+
+// recordResponse stores the metadata of a response so it can be read with LastResponse
+func (c *APIClient) recordResponse(resp *http.Response) {
+	meta := parseResponseMetadata(resp)
+
+	c.mu.Lock()
+	c.lastResponse = meta
+	c.mu.Unlock()
+}
+
+// parseResponseMetadata extracts the rate limit headers from a response
+func parseResponseMetadata(resp *http.Response) ResponseMetadata {
+	meta := ResponseMetadata{
+		StatusCode:         resp.StatusCode,
+		RateLimitLimit:     headerInt(resp.Header, "X-RateLimit-Limit"),
+		RateLimitRemaining: headerInt(resp.Header, "X-RateLimit-Remaining"),
+		RetryAfter:         parseRetryAfter(resp.Header.Get("Retry-After")),
+	}
+	if reset := headerInt(resp.Header, "X-RateLimit-Reset"); reset >= 0 {
+		meta.RateLimitReset = time.Unix(int64(reset), 0) // The reset header is a unix timestamp
+	}
+	return meta
+}
+
+// headerInt parses an integer header, returning -1 when it is missing or malformed
+func headerInt(header http.Header, key string) int {
+	n, err := strconv.Atoi(header.Get(key))
+	if err != nil {
+		return -1
+	}
+	return n
+}
+
+// parseRetryAfter parses a Retry-After value given either in seconds or as an HTTP date
+func parseRetryAfter(value string) time.Duration {
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		return time.Duration(seconds) * time.Second // Delay given in seconds
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		return max(time.Until(date), 0) // Delay given as a date
+	}
+	return 0
+}
+
+// newRateLimitError reads and closes a 429 response and converts it to a RateLimitError
+func newRateLimitError(resp *http.Response) *RateLimitError {
+	defer resp.Body.Close() // The caller never sees this response
+
+	body, _ := io.ReadAll(resp.Body) // The body is only used for the message
+	return &RateLimitError{
+		RetryAfter: parseRetryAfter(resp.Header.Get("Retry-After")),
+		Err:        newAPIError(resp, body),
+	}
+}
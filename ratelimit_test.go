@@ -0,0 +1,74 @@
+package cloudbet
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// TestRateLimitError tests that a 429 is returned as a RateLimitError when retry is disabled
+func TestRateLimitError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "7")
+		w.Header().Set("X-RateLimit-Remaining", "0")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	client := NewAPIClient(apikey, WithBaseURL(server.URL))
+
+	_, err := client.GetEvent(context.Background(), "1")
+	var rateErr *RateLimitError
+	if !errors.As(err, &rateErr) {
+		t.Fatalf("expected RateLimitError, got %v", err)
+	}
+	if rateErr.RetryAfter != 7*time.Second {
+		t.Fatalf("expected retry after 7s, got %v", rateErr.RetryAfter)
+	}
+	if meta := client.LastResponse(); meta.RateLimitRemaining != 0 || meta.StatusCode != http.StatusTooManyRequests {
+		t.Fatalf("unexpected metadata %+v", meta)
+	}
+}
+
+// TestRateLimitRetry tests that a 429 is retried when retry is enabled
+func TestRateLimitRetry(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) == 1 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.Header().Set("X-RateLimit-Limit", "100")
+		w.Header().Set("X-RateLimit-Remaining", "99")
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	client := NewAPIClient(apikey, WithBaseURL(server.URL), WithRetry(2, time.Millisecond))
+
+	if _, err := client.GetEvent(context.Background(), "1"); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if meta := client.LastResponse(); meta.RateLimitLimit != 100 || meta.RateLimitRemaining != 99 {
+		t.Fatalf("unexpected metadata %+v", meta)
+	}
+}
+
+// TestParseRetryAfter tests both Retry-After formats
+func TestParseRetryAfter(t *testing.T) {
+	if d := parseRetryAfter("3"); d != 3*time.Second {
+		t.Fatalf("expected 3s, got %v", d)
+	}
+	date := time.Now().Add(time.Minute).UTC().Format(http.TimeFormat)
+	if d := parseRetryAfter(date); d <= 0 || d > time.Minute {
+		t.Fatalf("expected a delay up to a minute, got %v", d)
+	}
+	if d := parseRetryAfter("soon"); d != 0 {
+		t.Fatalf("expected 0 for malformed value, got %v", d)
+	}
+}
@@ -0,0 +1,64 @@
+package cloudbet
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// GetEventWithRaw retrieves a specific event like GetEventJSON, also returning the raw response.
+// The raw response is returned whenever one was received, even if decoding failed
+func (c *APIClient) GetEventWithRaw(ctx context.Context, id string) (*Event, *RawResponse, error) {
+	raw, err := c.getEvent(ctx, "GetEventWithRaw", id)
+	if err != nil {
+		return nil, raw, err // Return error if the request fails
+	}
+
+	var event Event // Variable to hold the parsed event response
+	if err := c.decodeJSON("failed to get event", raw, &event); err != nil {
+		return nil, raw, err // Return error if the response is empty or not JSON
+	}
+	return &event, raw, nil
+}
+
+// GetTodayFixturesWithRaw retrieves today's fixtures like GetTodayFixturesJSON, also returning the raw response.
+// The raw response is returned whenever one was received, even if decoding failed
+func (c *APIClient) GetTodayFixturesWithRaw(ctx context.Context, sport SportKey, limit int) (*Fixtures, *RawResponse, error) {
+	raw, err := c.getFixturesByDate(ctx, "GetTodayFixturesWithRaw", sport, time.Now(), limit)
+	if err != nil {
+		return nil, raw, err // Return error if the request fails
+	}
+
+	var fixtures Fixtures // Variable to hold the parsed fixtures response
+	if err := c.decodeJSON("failed to get fixtures", raw, &fixtures); err != nil {
+		return nil, raw, err // Return error if the response is empty or not JSON
+	}
+	return &fixtures, raw, nil
+}
+
+// GetEventRaw retrieves a specific event by its ID as the untouched JSON body, without decoding it
+func (c *APIClient) GetEventRaw(ctx context.Context, id string) ([]byte, error) {
+	raw, err := c.getEvent(ctx, "GetEventRaw", id)
+	if err != nil {
+		return nil, err // Return error if the request fails
+	}
+	return raw.Body, nil
+}
+
+// GetEventPretty retrieves a specific event by its ID as indented JSON, for debugging. The body is
+// re-indented as is, it is not decoded into an Event
+func (c *APIClient) GetEventPretty(ctx context.Context, id string) (string, error) {
+	raw, err := c.getEvent(ctx, "GetEventPretty", id)
+	if err != nil {
+		return "", err // Return error if the request fails
+	}
+
+	var pretty bytes.Buffer
+	if err := json.Indent(&pretty, raw.Body, "", "  "); err != nil {
+		decodeErr := &DecodeError{StatusCode: raw.StatusCode, ContentType: raw.Header.Get("Content-Type"), Body: c.bodySnippet(raw.Body), Err: err}
+		return "", fmt.Errorf("failed to get event: %w", decodeErr) // Return error if the body is not JSON
+	}
+	return pretty.String(), nil
+}
@@ -0,0 +1,57 @@
+package cloudbet
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// TestGetEventWithRaw tests that the raw body is returned even when decoding fails
+func TestGetEventWithRaw(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Request-Id", "abc")
+		w.Write([]byte(`{"id":"not a number"}`))
+	}))
+	defer server.Close()
+
+	client := NewAPIClient(apikey, WithBaseURL(server.URL))
+
+	event, raw, err := client.GetEventWithRaw(context.Background(), "1")
+	if err == nil || event != nil {
+		t.Fatalf("expected a decode error, got %v", err)
+	}
+	if raw == nil || string(raw.Body) != `{"id":"not a number"}` || raw.Header.Get("X-Request-Id") != "abc" {
+		t.Fatalf("unexpected raw response %+v", raw)
+	}
+}
+
+// TestGetEventPretty tests that the body is returned untouched by GetEventRaw and indented by GetEventPretty
+func TestGetEventPretty(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/pub/v2/odds/events/html" {
+			w.Write([]byte(`<html></html>`))
+			return
+		}
+		w.Write([]byte(`{"id":1,"name":"Arsenal V Chelsea"}`))
+	}))
+	defer server.Close()
+
+	client := NewAPIClient(apikey, WithBaseURL(server.URL))
+	ctx := context.Background()
+
+	body, err := client.GetEventRaw(ctx, "1")
+	if err != nil || string(body) != `{"id":1,"name":"Arsenal V Chelsea"}` {
+		t.Fatalf("unexpected raw event %s, %v", body, err)
+	}
+	pretty, err := client.GetEventPretty(ctx, "1")
+	if err != nil || pretty != "{\n  \"id\": 1,\n  \"name\": \"Arsenal V Chelsea\"\n}" {
+		t.Fatalf("unexpected pretty event %q, %v", pretty, err)
+	}
+
+	var decodeErr *DecodeError
+	if _, err := client.GetEventPretty(ctx, "html"); !errors.As(err, &decodeErr) {
+		t.Fatalf("expected a DecodeError for an HTML body, got %v", err)
+	}
+}
@@ -0,0 +1,60 @@
+package cloudbet
+
+import (
+	"fmt"
+	"strings"
+)
+
+// maskAPIKey hides all but the prefix and the last four characters of an API key, e.g. "sk_****1234"
+func maskAPIKey(key string) string {
+	if len(key) <= 8 {
+		return "****" // Too short to reveal anything
+	}
+	prefix := ""
+	if i := strings.Index(key, "_"); i >= 0 && i < len(key)-8 {
+		prefix = key[:i+1] // Keep a prefix such as "sk_" to tell keys apart
+	}
+	return prefix + "****" + key[len(key)-4:]
+}
+
+// String returns a description of the client with the API key masked
+func (c *APIClient) String() string {
+	return fmt.Sprintf("APIClient{BaseURL: %s, APIKey: %s}", c.BaseURL, maskAPIKey(c.APIKey))
+}
+
+// GoString returns a Go-syntax like description of the client with the API key masked, used by %#v
+func (c *APIClient) GoString() string {
+	return fmt.Sprintf("&cloudbet.APIClient{BaseURL:%q, APIKey:%q}", c.BaseURL, maskAPIKey(c.APIKey))
+}
+
+// redact replaces every occurrence of the API key in s with its masked form
+func (c *APIClient) redact(s string) string {
+	if c.APIKey == "" {
+		return s
+	}
+	return strings.ReplaceAll(s, c.APIKey, maskAPIKey(c.APIKey))
+}
+
+// redactedError is an error whose message had the API key masked
+type redactedError struct {
+	msg string // Redacted message
+	err error  // Original error
+}
+
+// Error implements the error interface
+func (e *redactedError) Error() string {
+	return e.msg
+}
+
+// Unwrap returns the original error so errors.Is and errors.As keep working
+func (e *redactedError) Unwrap() error {
+	return e.err
+}
+
+// redactError masks the API key in the message of err, if it contains it
+func (c *APIClient) redactError(err error) error {
+	if err == nil || c.APIKey == "" || !strings.Contains(err.Error(), c.APIKey) {
+		return err // Nothing to redact
+	}
+	return &redactedError{msg: c.redact(err.Error()), err: err}
+}
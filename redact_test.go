@@ -0,0 +1,59 @@
+package cloudbet
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// TestMaskAPIKey tests the masked form of API keys
+func TestMaskAPIKey(t *testing.T) {
+	tests := map[string]string{
+		"sk_live_abcdef1234": "sk_****1234",
+		"abcdefghij1234":     "****1234",
+		"short":              "****",
+		"":                   "****",
+	}
+	for key, want := range tests {
+		if got := maskAPIKey(key); got != want {
+			t.Fatalf("maskAPIKey(%q) = %q, want %q", key, got, want)
+		}
+	}
+}
+
+// TestAPIClientFormatting tests that printing the client never shows the key
+func TestAPIClientFormatting(t *testing.T) {
+	const secret = "sk_live_abcdef1234"
+	client := NewAPIClient(secret)
+
+	for _, format := range []string{"%v", "%+v", "%#v", "%s"} {
+		out := fmt.Sprintf(format, client)
+		if strings.Contains(out, secret) || !strings.Contains(out, "sk_****1234") {
+			t.Fatalf("%s: unexpected output %q", format, out)
+		}
+	}
+}
+
+// TestErrorRedaction tests that errors echoing the key are masked
+func TestErrorRedaction(t *testing.T) {
+	const secret = "sk_live_abcdef1234"
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+		w.Write([]byte(`{"error":"invalid key ` + r.Header.Get("X-API-Key") + `"}`))
+	}))
+	defer server.Close()
+
+	client := NewAPIClient(secret, WithBaseURL(server.URL))
+
+	_, err := client.GetEvent(context.Background(), "1")
+	if err == nil || strings.Contains(err.Error(), secret) {
+		t.Fatalf("expected a redacted error, got %v", err)
+	}
+	bet, err := client.PlaceBet(context.Background(), PlaceBetPayload{})
+	if err == nil || strings.Contains(err.Error(), secret) || strings.Contains(bet.Error, secret) {
+		t.Fatalf("expected a redacted error, got %v", err)
+	}
+}
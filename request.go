@@ -0,0 +1,195 @@
+package cloudbet
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// RawResponse holds a response exactly as it was received, for debugging decode issues
+type RawResponse struct {
+	StatusCode int           // HTTP status code of the response
+	Header     http.Header   // Response headers
+	Body       []byte        // Original response body
+	Timing     RequestTiming // Time the call took until the body was read, retries included
+}
+
+// newRequest builds a request for path relative to the base URL with the headers every
+// Cloudbet endpoint expects and the WithHeader headers, all requests must be built with it
+func (c *APIClient) newRequest(ctx context.Context, method, path string, body io.Reader) (*http.Request, error) {
+	req, err := http.NewRequestWithContext(ctx, method, c.BaseURL+path, body)
+	if err != nil {
+		return nil, err // Return error if request creation fails
+	}
+	for key, values := range c.headers {
+		req.Header[key] = append([]string(nil), values...) // Custom headers first, so the ones below win
+	}
+	req.Header.Set("X-API-Key", c.APIKey)             // Set the API key in the header
+	req.Header.Set("Accept", "application/json")      // Set accept header for JSON response
+	req.Header.Set("User-Agent", c.userAgent)         // Identify the library on every request
+	req.Header.Set("Accept-Encoding", acceptEncoding) // Ask for compressed responses
+	if c.locale != "" {
+		req.Header.Set("Accept-Language", c.locale) // Ask for localized names
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json") // Set content type to JSON for requests with a body
+	}
+	return req, nil
+}
+
+// getRaw sends an idempotent GET request for path and returns the raw response,
+// op describes the operation in returned errors (e.g. "failed to get sports").
+// The raw response is also returned with the APIError of a non successful status
+func (c *APIClient) getRaw(ctx context.Context, method, op, path string) (*RawResponse, error) {
+	// Create a new GET request for the given path
+	req, err := c.newRequest(ctx, "GET", path, nil)
+	if err != nil {
+		return nil, err // Return error if request creation fails
+	}
+
+	resp, err := c.do(method, req, true) // Send the request
+	if err != nil {
+		return nil, c.redactError(contextError(ctx, op, err)) // Return error if request fails
+	}
+	defer resp.Body.Close() // Ensure the response body is closed after processing
+
+	body, err := io.ReadAll(resp.Body) // Read the response body
+	if err != nil {
+		return nil, c.redactError(contextError(ctx, op, readError(err))) // Return error if reading body fails
+	}
+
+	raw := &RawResponse{StatusCode: resp.StatusCode, Header: resp.Header, Body: body, Timing: responseTiming(resp)}
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNoContent {
+		apiErr := newAPIError(resp, body)
+		apiErr.Message = c.redact(apiErr.Message)    // The body may echo request details
+		return raw, fmt.Errorf("%s: %w", op, apiErr) // Return error if status is not OK
+	}
+	return raw, nil
+}
+
+// getJSON sends an idempotent GET request for path and decodes the JSON response into v
+func (c *APIClient) getJSON(ctx context.Context, method, op, path string, v any) error {
+	raw, err := c.getRaw(ctx, method, op, path)
+	if err != nil {
+		return err // Return error if the request fails
+	}
+	return c.decodeJSON(op, raw, v) // Decode the response into v
+}
+
+// maxBodySnippet is the number of body bytes quoted in decoding errors
+const maxBodySnippet = 200
+
+// decodeJSON decodes the body of a successful response into v, keeping numbers exact (see unmarshalNumbers).
+// A 204 No Content response leaves v untouched, empty and non JSON bodies such as HTML error pages give an
+// error quoting the body
+func (c *APIClient) decodeJSON(op string, raw *RawResponse, v any) error {
+	if raw.StatusCode == http.StatusNoContent {
+		return nil // Nothing to decode, v keeps its zero value
+	}
+
+	body := bytes.TrimSpace(raw.Body)
+	decodeErr := &DecodeError{StatusCode: raw.StatusCode, ContentType: raw.Header.Get("Content-Type")}
+	if len(body) == 0 {
+		return fmt.Errorf("%s: %w", op, decodeErr) // Return error if there is nothing to decode
+	}
+	if body[0] == '<' || strings.Contains(decodeErr.ContentType, "html") {
+		decodeErr.Body = c.bodySnippet(body)
+		return fmt.Errorf("%s: %w", op, decodeErr) // Return error if the body is an HTML page
+	}
+	if err := unmarshalNumbers(body, v); err != nil {
+		decodeErr.Body, decodeErr.Err = c.bodySnippet(body), err
+		return fmt.Errorf("%s: %w", op, decodeErr) // Return error if the JSON is invalid
+	}
+	return nil
+}
+
+// unmarshalNumbers decodes a single JSON value into v like json.Unmarshal, but numbers decoded into
+// interface values are kept as exact json.Number instead of float64, so prices do not drift
+func unmarshalNumbers(data []byte, v any) error {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	if err := dec.Decode(v); err != nil {
+		return err
+	}
+	if _, err := dec.Token(); err != io.EOF {
+		return errors.New("invalid character after top-level value") // Reject trailing data as json.Unmarshal does
+	}
+	return nil
+}
+
+// bodySnippet returns the start of a body for error messages, with the API key redacted
+func (c *APIClient) bodySnippet(body []byte) string {
+	if len(body) > maxBodySnippet {
+		return c.redact(string(body[:maxBodySnippet])) + "..."
+	}
+	return c.redact(string(body))
+}
+
+// send performs a single attempt of a request, running the hooks and tracing it to the logger
+func (c *APIClient) send(req *http.Request) (*http.Response, error) {
+	client := c.Client
+	if _, ok := req.Context().Deadline(); ok && client.Timeout > 0 {
+		withoutTimeout := *client
+		withoutTimeout.Timeout = 0 // The context deadline takes precedence over the client timeout
+		client = &withoutTimeout
+	}
+
+	c.runRequestHooks(req) // Let callers decorate the request
+
+	start := time.Now()
+	resp, err := client.Do(req) // Send the request
+	if err != nil {
+		c.logf("cloudbet: %s %s failed after %v: %v", req.Method, req.URL.Redacted(), time.Since(start), err)
+		return nil, &NetworkError{Err: err}
+	}
+	c.logf("cloudbet: %s %s -> %d in %v", req.Method, req.URL.Redacted(), resp.StatusCode, time.Since(start))
+	if err := decompressBody(resp); err != nil {
+		resp.Body.Close()
+		return nil, &DecodeError{StatusCode: resp.StatusCode, ContentType: resp.Header.Get("Content-Type"), Err: err} // Return error if the compressed body is corrupt
+	}
+	if c.maxResponseBytes > 0 {
+		resp.Body = &limitedBody{ReadCloser: resp.Body, remaining: c.maxResponseBytes, limit: c.maxResponseBytes} // Bound the decompressed size
+	}
+	c.runResponseHooks(resp) // Let callers observe the response
+	return resp, nil
+}
+
+// limitedBody is a response body failing with ErrResponseTooLarge once more than limit bytes are read
+type limitedBody struct {
+	io.ReadCloser
+	remaining int64 // Bytes that may still be read, negative once the limit was exceeded
+	limit     int64 // Maximum size of the body
+}
+
+// Read reads from the body, returning ErrResponseTooLarge instead of the bytes past the limit
+func (b *limitedBody) Read(p []byte) (int, error) {
+	if b.remaining < 0 {
+		return 0, b.tooLarge()
+	}
+	allowed := b.remaining
+	n, err := b.ReadCloser.Read(p[:min(int64(len(p)), allowed+1)]) // Read one byte past the limit to detect it
+	b.remaining -= int64(n)
+	if b.remaining < 0 {
+		return int(allowed), b.tooLarge()
+	}
+	return n, err
+}
+
+// tooLarge returns the error reported once the limit is exceeded
+func (b *limitedBody) tooLarge() error {
+	return fmt.Errorf("%w: more than %d bytes", ErrResponseTooLarge, b.limit)
+}
+
+// readError wraps an error reading a response body, oversized bodies are not network errors
+func readError(err error) error {
+	if errors.Is(err, ErrResponseTooLarge) {
+		return err
+	}
+	return &NetworkError{Err: err}
+}
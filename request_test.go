@@ -0,0 +1,103 @@
+package cloudbet
+
+import (
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// TestDecodeJSONResponses tests that empty, 204 and non JSON responses are handled descriptively
+func TestDecodeJSONResponses(t *testing.T) {
+	tests := []struct {
+		name, contentType, body string
+		status                  int
+		wantErr                 string
+	}{
+		{name: "no content", status: http.StatusNoContent},
+		{name: "empty", status: http.StatusOK, wantErr: "empty response body (status 200)"},
+		{name: "html", contentType: "text/html", body: "<html><body>Bad gateway</body></html>", status: http.StatusOK, wantErr: "<html><body>Bad gateway"},
+		{name: "truncated", contentType: "application/json", body: `{"amount":`, status: http.StatusOK, wantErr: `invalid JSON response (status 200)`},
+		{name: "trailing data", contentType: "application/json", body: `{"amount":"1"} {}`, status: http.StatusOK, wantErr: `invalid JSON response (status 200)`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if tt.contentType != "" {
+					w.Header().Set("Content-Type", tt.contentType)
+				}
+				w.WriteHeader(tt.status)
+				w.Write([]byte(tt.body))
+			}))
+			defer server.Close()
+
+			client := NewAPIClient(apikey, WithBaseURL(server.URL))
+
+			var balance Balance
+			err := client.getJSON(context.Background(), "AccountBalance", "failed to get balance", "/balance", &balance)
+			if tt.wantErr == "" {
+				if err != nil || !balance.Amount.IsZero() {
+					t.Fatalf("expected success with a zero result, got %+v, %v", balance, err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) || !strings.HasPrefix(err.Error(), "failed to get balance") {
+				t.Fatalf("expected an error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+// TestDecodeJSONNumbers tests that numbers decoded into interface values keep every digit
+func TestDecodeJSONNumbers(t *testing.T) {
+	client := NewAPIClient(apikey)
+	raw := &RawResponse{StatusCode: http.StatusOK, Header: http.Header{}, Body: []byte(`{"price":1.123456789012345678}`)}
+
+	var v map[string]any
+	if err := client.decodeJSON("failed to decode", raw, &v); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if n, ok := v["price"].(json.Number); !ok || n.String() != "1.123456789012345678" {
+		t.Fatalf("expected an exact json.Number, got %#v", v["price"])
+	}
+}
+
+// TestWithMaxResponseBytes tests that bodies past the limit fail, also once decompressed
+func TestWithMaxResponseBytes(t *testing.T) {
+	body := `{"id":1,"name":"` + strings.Repeat("a", 100) + `"}`
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/pub/v2/odds/events/gzip" {
+			w.Header().Set("Content-Encoding", "gzip")
+			gz := gzip.NewWriter(w)
+			gz.Write([]byte(body))
+			gz.Close()
+			return
+		}
+		w.Write([]byte(body))
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	client := NewAPIClient(apikey, WithBaseURL(server.URL), WithMaxResponseBytes(50))
+	for _, id := range []string{"1", "gzip"} {
+		_, err := client.GetEventJSON(ctx, id)
+		var networkErr *NetworkError
+		if !errors.Is(err, ErrResponseTooLarge) || errors.As(err, &networkErr) {
+			t.Fatalf("event %s: expected ErrResponseTooLarge, got %v", id, err)
+		}
+	}
+
+	for _, limit := range []int64{int64(len(body)), 0} {
+		client := NewAPIClient(apikey, WithBaseURL(server.URL), WithMaxResponseBytes(limit))
+		if event, err := client.GetEventJSON(ctx, "gzip"); err != nil || event.ID != 1 {
+			t.Fatalf("limit %d: expected the event, got %+v, %v", limit, event, err)
+		}
+	}
+	if client := NewAPIClient(apikey); client.maxResponseBytes != DefaultMaxResponseBytes {
+		t.Fatalf("expected the default limit, got %d", client.maxResponseBytes)
+	}
+}
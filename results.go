@@ -0,0 +1,108 @@
+package cloudbet
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Winners returns the winning selections of every settled market, keyed by market key
+func (s Settlement) Winners() map[string][]SelectionResult {
+	winners := make(map[string][]SelectionResult)
+	for marketKey, market := range s {
+		for _, submarket := range market.Submarkets {
+			for _, selection := range submarket.Selections {
+				if selection.Result == "WIN" {
+					winners[marketKey] = append(winners[marketKey], selection)
+				}
+			}
+		}
+	}
+	return winners
+}
+
+// GetSettledEvent retrieves a resulted event with its settlement, end time and resulted time, to grade
+// a finished match. It returns ErrEventNotResulted while the event has not been resulted yet
+func (c *APIClient) GetSettledEvent(ctx context.Context, eventID string) (*Event, error) {
+	event, err := c.GetEventJSON(ctx, eventID) // Resulted events carry their settlement
+	if err != nil {
+		return nil, err
+	}
+	if event.Status != EventStatusResulted || len(event.Settlement) == 0 {
+		return nil, fmt.Errorf("failed to get event result for %s: %w (status %s)", eventID, ErrEventNotResulted, event.Status)
+	}
+	return event, nil
+}
+
+// GetEventResult retrieves the settlement of a resulted event. It returns ErrEventNotResulted
+// while the event has not been resulted yet
+func (c *APIClient) GetEventResult(ctx context.Context, eventID string) (*Settlement, error) {
+	event, err := c.GetSettledEvent(ctx, eventID)
+	if err != nil {
+		return nil, err
+	}
+	return &event.Settlement, nil
+}
+
+// Grade returns how the selection was settled
+func (r SelectionResult) Grade() GradeResult {
+	return parseGradeResult(r.Result)
+}
+
+// GradeResult defines how a selection was settled
+type GradeResult string
+
+const (
+	GradeWin      GradeResult = "WIN"       // The selection won in full
+	GradeLoss     GradeResult = "LOSS"      // The selection lost in full
+	GradeHalfWin  GradeResult = "HALF_WIN"  // Half of the stake won, the other half was refunded
+	GradeHalfLoss GradeResult = "HALF_LOSS" // Half of the stake lost, the other half was refunded
+	GradePush     GradeResult = "PUSH"      // The line was hit exactly and the stake refunded
+	GradeVoid     GradeResult = "VOID"      // The selection was cancelled and the stake refunded
+	GradeUnknown  GradeResult = "UNKNOWN"   // Cloudbet reported a result this library does not know
+)
+
+// parseGradeResult maps a Cloudbet settlement result to a GradeResult
+func parseGradeResult(result string) GradeResult {
+	switch grade := GradeResult(strings.ToUpper(result)); grade {
+	case GradeWin, GradeLoss, GradeHalfWin, GradeHalfLoss, GradePush, GradeVoid:
+		return grade
+	case "CANCELLED", "CANCELED", "VOIDED":
+		return GradeVoid // Cancellations refund the stake like a void
+	default:
+		return GradeUnknown
+	}
+}
+
+// sameParams reports whether two selection params strings hold the same pairs, in any order
+func sameParams(a, b string) bool {
+	splitParams := func(params string) []string {
+		if params == "" {
+			return nil
+		}
+		pairs := strings.Split(params, "&")
+		slices.Sort(pairs)
+		return pairs
+	}
+	return slices.Equal(splitParams(a), splitParams(b))
+}
+
+// GradeSelection reports how a selection of a resulted event was settled, keeping voids, pushes and
+// half results apart from wins and losses. It returns ErrEventNotResulted before the event is resulted
+// and ErrSelectionNotFound when the settlement has no such selection
+func (c *APIClient) GradeSelection(ctx context.Context, eventID, marketKey, outcome, params string) (GradeResult, error) {
+	settlement, err := c.GetEventResult(ctx, eventID)
+	if err != nil {
+		return "", err
+	}
+
+	for _, submarket := range (*settlement)[marketKey].Submarkets {
+		for _, selection := range submarket.Selections {
+			if selection.Outcome == outcome && sameParams(selection.Params, params) {
+				return selection.Grade(), nil
+			}
+		}
+	}
+	return "", fmt.Errorf("failed to grade %s/%s?%s of event %s: %w", marketKey, outcome, params, eventID, ErrSelectionNotFound)
+}
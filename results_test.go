@@ -0,0 +1,99 @@
+package cloudbet
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// TestGetEventResult tests that the settlement of a resulted event is parsed
+func TestGetEventResult(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.ServeFile(w, r, "cloudbettest/testdata/event_resulted.json")
+	}))
+	defer server.Close()
+
+	client := NewAPIClient(apikey, WithBaseURL(server.URL))
+
+	settlement, err := client.GetEventResult(context.Background(), "24055338")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	winners := settlement.Winners()
+	if len(winners["soccer.match_odds"]) != 1 || winners["soccer.match_odds"][0].Outcome != "home" {
+		t.Fatalf("unexpected match odds winners %+v", winners)
+	}
+	if len(winners["soccer.total_goals"]) != 1 || winners["soccer.total_goals"][0].Outcome != "under" {
+		t.Fatalf("unexpected total goals winners %+v", winners)
+	}
+}
+
+// TestGetSettledEvent tests that a resulted event carries its settlement and result times
+func TestGetSettledEvent(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.ServeFile(w, r, "cloudbettest/testdata/event_resulted.json")
+	}))
+	defer server.Close()
+
+	client := NewAPIClient(apikey, WithBaseURL(server.URL))
+
+	event, err := client.GetSettledEvent(context.Background(), "24055338")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if !event.EndTime.Equal(time.Date(2024, 5, 19, 16, 52, 0, 0, time.UTC)) || !event.ResultedTime.Equal(time.Date(2024, 5, 19, 17, 5, 31, 0, time.UTC)) {
+		t.Fatalf("unexpected end time %v and resulted time %v", event.EndTime, event.ResultedTime)
+	}
+	selections := event.Settlement["soccer.asian_handicap"].Submarkets["period=ft"].Selections
+	if len(selections) != 4 || selections[0].Grade() != GradePush || selections[2].Grade() != GradeHalfWin {
+		t.Fatalf("unexpected asian handicap settlement %+v", selections)
+	}
+}
+
+// TestGetEventResultNotResulted tests that an event still trading is reported as not resulted
+func TestGetEventResultNotResulted(t *testing.T) {
+	client, closeServer := newTestClient(nil)
+	defer closeServer()
+
+	if _, err := client.GetEventResult(context.Background(), "24055338"); !errors.Is(err, ErrEventNotResulted) {
+		t.Fatalf("expected ErrEventNotResulted, got %v", err)
+	}
+}
+
+// TestGradeSelection tests that every kind of result is graded distinctly
+func TestGradeSelection(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.ServeFile(w, r, "cloudbettest/testdata/event_resulted.json")
+	}))
+	defer server.Close()
+
+	client := NewAPIClient(apikey, WithBaseURL(server.URL))
+
+	tests := []struct {
+		marketKey, outcome, params string
+		want                       GradeResult
+	}{
+		{"soccer.match_odds", "home", "", GradeWin},
+		{"soccer.match_odds", "draw", "", GradeLoss},
+		{"soccer.asian_handicap", "home", "handicap=-1", GradePush},
+		{"soccer.asian_handicap", "home", "handicap=-0.75", GradeHalfWin},
+		{"soccer.asian_handicap", "away", "handicap=0.75", GradeHalfLoss},
+		{"soccer.total_goals", "over", "total=3.5&period=ft", GradeVoid}, // Params match in any order
+	}
+	for _, tt := range tests {
+		grade, err := client.GradeSelection(context.Background(), "24055338", tt.marketKey, tt.outcome, tt.params)
+		if err != nil {
+			t.Fatalf("%s/%s?%s: expected no error, got %v", tt.marketKey, tt.outcome, tt.params, err)
+		}
+		if grade != tt.want {
+			t.Fatalf("%s/%s?%s: expected %s, got %s", tt.marketKey, tt.outcome, tt.params, tt.want, grade)
+		}
+	}
+
+	if _, err := client.GradeSelection(context.Background(), "24055338", "soccer.total_goals", "over", "total=4.5"); !errors.Is(err, ErrSelectionNotFound) {
+		t.Fatalf("expected ErrSelectionNotFound, got %v", err)
+	}
+}
@@ -0,0 +1,119 @@
+package cloudbet
+
+import (
+	"context"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"time"
+)
+
+// retryPolicy holds the retry configuration of the client
+type retryPolicy struct {
+	maxAttempts int           // Maximum number of attempts, including the first one
+	baseDelay   time.Duration // Delay before the first retry, doubled on every attempt
+}
+
+// WithRetry enables retrying idempotent requests on 429 and 5xx responses and network errors,
+// waiting an exponentially growing, jittered delay (or the Retry-After delay) between attempts.
+// PlaceBet is never retried
+func WithRetry(maxAttempts int, baseDelay time.Duration) Option {
+	return func(c *APIClient) {
+		c.retry = retryPolicy{maxAttempts: maxAttempts, baseDelay: baseDelay}
+	}
+}
+
+// do sends the request after waiting for the rate limiter, retrying it on transient failures
+// when it is idempotent and retry is enabled. The call is reported to the metrics collector under
+// method, the exported APIClient method making it (e.g. "GetEventJSON")
+func (c *APIClient) do(method string, req *http.Request, idempotent bool) (*http.Response, error) {
+	if c.metrics == nil {
+		return c.doAttempts(req, idempotent)
+	}
+
+	start := time.Now()
+	resp, err := c.doAttempts(req, idempotent)
+	c.observe(method, start, resp, err)
+	return resp, err
+}
+
+// doAttempts sends the request until it succeeds or may no longer be retried
+func (c *APIClient) doAttempts(req *http.Request, idempotent bool) (*http.Response, error) {
+	attempts := 1
+	if idempotent && c.retry.maxAttempts > 1 {
+		attempts = c.retry.maxAttempts // Only idempotent requests may be sent more than once
+	}
+
+	var start time.Time // Time the first attempt was sent
+	for attempt := 1; ; attempt++ {
+		if c.isClosed() {
+			return nil, ErrClosed // Return error if the client was closed, also between retries
+		}
+		if c.limiter != nil {
+			if err := c.limiter.Wait(req.Context()); err != nil {
+				return nil, err // Return error if the context ends before a token is available
+			}
+		}
+
+		attemptStart := time.Now()
+		if attempt == 1 {
+			start = attemptStart
+		}
+		resp, err := c.send(req.Clone(req.Context())) // Send a fresh copy of the request
+		if err == nil {
+			c.recordResponse(resp) // Keep the rate limit headers for LastResponse
+		}
+		rateLimited := err == nil && resp.StatusCode == http.StatusTooManyRequests
+		if attempt >= attempts || !shouldRetry(req.Context(), resp, err) {
+			if err == nil {
+				c.timeBody(resp, start, attemptStart, attempt) // Time the call until its body is read
+			}
+			if rateLimited {
+				return nil, newRateLimitError(resp) // Surface the requested delay to the caller
+			}
+			return resp, err // Return the result if it is final
+		}
+
+		delay := c.retry.backoff(attempt)
+		if resp != nil {
+			if rateLimited {
+				delay = max(delay, parseRetryAfter(resp.Header.Get("Retry-After"))) // Wait at least as long as asked
+			}
+			io.Copy(io.Discard, resp.Body) // Drain the body so the connection can be reused
+			resp.Body.Close()
+		}
+		if err := sleepContext(req.Context(), delay); err != nil {
+			return nil, err // Stop retrying once the context is done
+		}
+	}
+}
+
+// shouldRetry reports whether a request failed in a way that is worth retrying
+func shouldRetry(ctx context.Context, resp *http.Response, err error) bool {
+	if err != nil {
+		return ctx.Err() == nil // Retry network errors unless the caller gave up
+	}
+	return resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= http.StatusInternalServerError // Retry rate limits and server errors
+}
+
+// backoff returns the jittered delay to wait after the given attempt
+func (p retryPolicy) backoff(attempt int) time.Duration {
+	delay := p.baseDelay << (attempt - 1) // Double the delay on every attempt
+	if delay <= 0 {
+		return 0
+	}
+	return delay/2 + rand.N(delay/2+1) // Add jitter in the upper half of the delay
+}
+
+// sleepContext waits for the given duration or until the context is done
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err() // Return the context error if it finished first
+	case <-timer.C:
+		return nil
+	}
+}
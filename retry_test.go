@@ -0,0 +1,72 @@
+package cloudbet
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// TestRetryIdempotent tests that GET requests are retried on server errors
+func TestRetryIdempotent(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable) // Fail the first two attempts
+			return
+		}
+		w.Write([]byte(`{"id":1}`))
+	}))
+	defer server.Close()
+
+	client := NewAPIClient(apikey, WithBaseURL(server.URL), WithRetry(3, time.Millisecond))
+
+	if _, err := client.GetEvent(context.Background(), "1"); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if calls.Load() != 3 {
+		t.Fatalf("expected 3 attempts, got %d", calls.Load())
+	}
+}
+
+// TestRetrySkipsPlaceBet tests that placing a bet is never retried automatically
+func TestRetrySkipsPlaceBet(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	client := NewAPIClient(apikey, WithBaseURL(server.URL), WithRetry(3, time.Millisecond))
+
+	if _, err := client.PlaceBet(context.Background(), PlaceBetPayload{}); err == nil {
+		t.Fatal("expected an error")
+	}
+	if calls.Load() != 1 {
+		t.Fatalf("expected 1 attempt, got %d", calls.Load())
+	}
+}
+
+// TestRetryRespectsContext tests that the backoff sleep stops when the context is done
+func TestRetryRespectsContext(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	client := NewAPIClient(apikey, WithBaseURL(server.URL), WithRetry(5, time.Hour))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	if _, err := client.AccountBalance(ctx, "PLAY_EUR"); err == nil {
+		t.Fatal("expected an error")
+	}
+	if time.Since(start) > time.Second {
+		t.Fatalf("expected retry to stop with the context, took %v", time.Since(start))
+	}
+}
@@ -0,0 +1,104 @@
+package cloudbet
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// sportsResponse defines the structure for the sports list response
+type sportsResponse struct {
+	Sports []Sport `json:"sports"` // List of sports
+}
+
+// GetSports retrieves every sport offered by Cloudbet, with its event and competition counts
+func (c *APIClient) GetSports(ctx context.Context) ([]Sport, error) {
+	var sports sportsResponse // Variable to hold the sports response
+	if err := c.getCachedJSON(ctx, "GetSports", "failed to get sports", c.oddsPath(oddsSportsPath), &sports); err != nil {
+		return nil, err // Return error if the request fails
+	}
+	return sports.Sports, nil
+}
+
+// sportCategory defines the structure for a category listed within a sport
+type sportCategory struct {
+	Name         string         `json:"name"`         // Name of the category
+	Key          string         `json:"key"`          // Key for the category
+	Competitions []Competitions `json:"competitions"` // Competitions within the category
+}
+
+// sportDetails defines the structure for the single sport response
+type sportDetails struct {
+	Name       string          `json:"name"`       // Name of the sport
+	Key        SportKey        `json:"key"`        // Key for the sport
+	Categories []sportCategory `json:"categories"` // Categories of the sport
+}
+
+// getSport retrieves a sport with its categories and competitions
+func (c *APIClient) getSport(ctx context.Context, method, op string, sport SportKey) (*sportDetails, error) {
+	var details sportDetails // Variable to hold the sport response
+	if err := c.getCachedJSON(ctx, method, op, c.oddsPath(fmt.Sprintf(oddsSportPath, url.PathEscape(string(sport)))), &details); err != nil {
+		return nil, err // Return error if the request fails
+	}
+	return &details, nil
+}
+
+// GetCategories retrieves the categories (countries or regions) of a sport, in the order Cloudbet lists them.
+// Use GetCompetitionsByCategory to list the competitions of one
+func (c *APIClient) GetCategories(ctx context.Context, sport SportKey) ([]Category, error) {
+	details, err := c.getSport(ctx, "GetCategories", "failed to get categories", sport)
+	if err != nil {
+		return nil, err
+	}
+
+	categories := make([]Category, 0, len(details.Categories))
+	for _, category := range details.Categories {
+		categories = append(categories, Category{Name: category.Name, Key: category.Key})
+	}
+	return categories, nil
+}
+
+// GetCompetitions retrieves every competition of a sport, with its category and sport populated
+func (c *APIClient) GetCompetitions(ctx context.Context, sport SportKey) ([]Competitions, error) {
+	details, err := c.getSport(ctx, "GetCompetitions", "failed to get competitions", sport)
+	if err != nil {
+		return nil, err
+	}
+
+	var competitions []Competitions
+	for _, category := range details.Categories {
+		for _, competition := range category.Competitions {
+			competition.Category = Category{Name: category.Name, Key: category.Key} // The category is only given by the parent
+			competition.Sport = Sport{Name: details.Name, Key: details.Key}         // As is the sport
+			competitions = append(competitions, competition)
+		}
+	}
+	return competitions, nil
+}
+
+// GetCompetitionsByCategory retrieves the competitions of a sport in one category (e.g. "england"),
+// with the category name populated for display. An unknown category gives no competitions
+func (c *APIClient) GetCompetitionsByCategory(ctx context.Context, sport SportKey, categoryKey string) ([]Competitions, error) {
+	competitions, err := c.GetCompetitions(ctx, sport)
+	if err != nil {
+		return nil, err
+	}
+
+	var filtered []Competitions
+	for _, competition := range competitions {
+		if strings.EqualFold(competition.Category.Key, categoryKey) {
+			filtered = append(filtered, competition)
+		}
+	}
+	return filtered, nil
+}
+
+// GetEventsByCompetition retrieves the events of a competition, including their markets
+func (c *APIClient) GetEventsByCompetition(ctx context.Context, competitionKey CompetitionKey) ([]Events, error) {
+	var competition Competitions // Variable to hold the competition response
+	if err := c.getJSON(ctx, "GetEventsByCompetition", "failed to get competition events", c.oddsPath(fmt.Sprintf(oddsCompetitionPath, url.PathEscape(string(competitionKey)))), &competition); err != nil {
+		return nil, err // Return error if the request fails
+	}
+	return competition.Events, nil
+}
@@ -0,0 +1,127 @@
+package cloudbet
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// TestGetSports tests the GetSports method of the API client
+func TestGetSports(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/pub/v2/odds/sports" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		w.Write([]byte(`{"sports":[{"name":"Soccer","key":"soccer","competitionCount":412,"eventCount":1830},{"name":"Tennis","key":"tennis","competitionCount":37,"eventCount":214}]}`))
+	}))
+	defer server.Close()
+
+	client := NewAPIClient(apikey, WithBaseURL(server.URL))
+
+	sports, err := client.GetSports(context.Background())
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(sports) != 2 || sports[0].Key != "soccer" || sports[0].EventCount != 1830 {
+		t.Fatalf("unexpected sports %+v", sports)
+	}
+}
+
+// TestGetCompetitions tests that competitions carry their category and sport
+func TestGetCompetitions(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/pub/v2/odds/sports/soccer" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		w.Write([]byte(`{"name":"Soccer","key":"soccer","categories":[
+			{"name":"England","key":"england","competitions":[{"name":"Premier League","key":"soccer-england-premier-league"}]},
+			{"name":"Spain","key":"spain","competitions":[{"name":"LaLiga","key":"soccer-spain-laliga"}]}]}`))
+	}))
+	defer server.Close()
+
+	client := NewAPIClient(apikey, WithBaseURL(server.URL))
+
+	competitions, err := client.GetCompetitions(context.Background(), "soccer")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(competitions) != 2 {
+		t.Fatalf("expected 2 competitions, got %d", len(competitions))
+	}
+	if competitions[1].Category.Key != "spain" || competitions[1].Sport.Key != "soccer" {
+		t.Fatalf("unexpected competition %+v", competitions[1])
+	}
+}
+
+// TestGetEventsByCompetition tests that competition events are returned with their markets
+func TestGetEventsByCompetition(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/pub/v2/odds/competitions/soccer-england-premier-league" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		w.Write([]byte(`{"key":"soccer-england-premier-league","events":[{"id":1,"markets":{"soccer.match_odds":{"submarkets":{"period=ft":{"selections":[{"outcome":"home","price":1.9}]}}}}}]}`))
+	}))
+	defer server.Close()
+
+	client := NewAPIClient(apikey, WithBaseURL(server.URL))
+
+	events, err := client.GetEventsByCompetition(context.Background(), "soccer-england-premier-league")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(events) != 1 {
+		t.Fatalf("expected 1 event, got %d", len(events))
+	}
+	if sel := events[0].Markets["soccer.match_odds"].Submarkets["period=ft"].Selections; len(sel) != 1 || sel[0].Price != 1.9 {
+		t.Fatalf("unexpected markets %+v", events[0].Markets)
+	}
+}
+
+// TestGetCompetitionsByCategory tests that only the competitions of the category are returned
+func TestGetCompetitionsByCategory(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"name":"Soccer","key":"soccer","categories":[
+			{"name":"England","key":"england","competitions":[{"name":"Premier League","key":"soccer-england-premier-league"},{"name":"Championship","key":"soccer-england-championship"}]},
+			{"name":"Spain","key":"spain","competitions":[{"name":"LaLiga","key":"soccer-spain-laliga"}]}]}`))
+	}))
+	defer server.Close()
+
+	client := NewAPIClient(apikey, WithBaseURL(server.URL))
+
+	competitions, err := client.GetCompetitionsByCategory(context.Background(), "soccer", "england")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(competitions) != 2 || competitions[0].Category.Name != "England" || competitions[1].Key != "soccer-england-championship" {
+		t.Fatalf("unexpected competitions %+v", competitions)
+	}
+
+	competitions, err = client.GetCompetitionsByCategory(context.Background(), "soccer", "narnia")
+	if err != nil || len(competitions) != 0 {
+		t.Fatalf("expected no competitions for an unknown category, got %+v, %v", competitions, err)
+	}
+}
+
+// TestGetCategories tests that the categories of a sport are listed
+func TestGetCategories(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/pub/v2/odds/sports/soccer" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		w.Write([]byte(`{"name":"Soccer","key":"soccer","categories":[
+			{"name":"England","key":"england","competitions":[{"name":"Premier League","key":"soccer-england-premier-league"}]},
+			{"name":"Spain","key":"spain","competitions":[]}]}`))
+	}))
+	defer server.Close()
+
+	client := NewAPIClient(apikey, WithBaseURL(server.URL))
+
+	categories, err := client.GetCategories(context.Background(), "soccer")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(categories) != 2 || categories[0] != (Category{Name: "England", Key: "england"}) || categories[1].Key != "spain" {
+		t.Fatalf("unexpected categories %+v", categories)
+	}
+}
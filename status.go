@@ -0,0 +1,133 @@
+package cloudbet
+
+import "encoding/json"
+
+// BetStatus defines the state of a bet. Values Cloudbet adds later decode as BetStatusUnknown
+type BetStatus string
+
+const (
+	BetStatusAccepted          BetStatus = "ACCEPTED"           // The bet was accepted and awaits settlement
+	BetStatusPendingAcceptance BetStatus = "PENDING_ACCEPTANCE" // The bet is still being processed
+	BetStatusRejected          BetStatus = "REJECTED"           // The bet was rejected
+	BetStatusWin               BetStatus = "WIN"                // The bet won
+	BetStatusLoss              BetStatus = "LOSS"               // The bet lost
+	BetStatusHalfWin           BetStatus = "HALF_WIN"           // Half of the stake won, the other half was refunded
+	BetStatusHalfLoss          BetStatus = "HALF_LOSS"          // Half of the stake lost, the other half was refunded
+	BetStatusPush              BetStatus = "PUSH"               // The stake was refunded
+	BetStatusVoid              BetStatus = "VOID"               // The bet was cancelled and the stake refunded
+
+	// Rejection reasons reported in the status of a rejected bet, the codes matched by the sentinel errors
+	BetStatusInsufficientFunds  BetStatus = "INSUFFICIENT_FUNDS"
+	BetStatusMarketSuspended    BetStatus = "MARKET_SUSPENDED"
+	BetStatusSelectionSuspended BetStatus = "SELECTION_SUSPENDED"
+	BetStatusMarketClosed       BetStatus = "MARKET_CLOSED"
+	BetStatusPriceAboveMarket   BetStatus = "PRICE_ABOVE_MARKET"
+	BetStatusPriceChanged       BetStatus = "PRICE_CHANGED"
+	BetStatusStakeTooLow        BetStatus = "STAKE_TOO_LOW"
+	BetStatusStakeBelowMinBet   BetStatus = "STAKE_BELOW_MIN_BET"
+
+	BetStatusUnknown BetStatus = "UNKNOWN" // A status this library does not know
+)
+
+// betStatuses lists the known bet statuses other than the rejection codes, which are known from codeErrors
+var betStatuses = map[BetStatus]bool{
+	BetStatusAccepted: true, BetStatusPendingAcceptance: true, BetStatusRejected: true,
+	BetStatusWin: true, BetStatusLoss: true, BetStatusHalfWin: true, BetStatusHalfLoss: true,
+	BetStatusPush: true, BetStatusVoid: true,
+}
+
+// IsSettled reports whether the bet has been settled
+func (s BetStatus) IsSettled() bool {
+	switch s {
+	case BetStatusWin, BetStatusLoss, BetStatusHalfWin, BetStatusHalfLoss, BetStatusPush, BetStatusVoid:
+		return true
+	}
+	return false
+}
+
+// IsRejected reports whether the bet was rejected, either generally or for one of the reasons matched
+// by the sentinel errors
+func (s BetStatus) IsRejected() bool {
+	return s == BetStatusRejected || isRejectionCode(string(s))
+}
+
+// IsOpen reports whether the bet is still awaiting settlement
+func (s BetStatus) IsOpen() bool {
+	return s == BetStatusAccepted || s == BetStatusPendingAcceptance
+}
+
+// UnmarshalJSON decodes a bet status, mapping unknown values to BetStatusUnknown
+func (s *BetStatus) UnmarshalJSON(data []byte) error {
+	var value string
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+	*s = BetStatus(value)
+	if value != "" && !betStatuses[*s] && !isRejectionCode(value) {
+		*s = BetStatusUnknown // Do not fail on statuses added after this library
+	}
+	return nil
+}
+
+// EventStatus defines the state of an event. Values Cloudbet adds later decode as EventStatusUnknown
+type EventStatus string
+
+const (
+	EventStatusPreTrading   EventStatus = "PRE_TRADING"   // The event is listed but not open for betting yet
+	EventStatusTrading      EventStatus = "TRADING"       // Pre-match betting is open
+	EventStatusTradingLive  EventStatus = "TRADING_LIVE"  // In-play betting is open
+	EventStatusInterrupted  EventStatus = "INTERRUPTED"   // Betting is paused
+	EventStatusAfterTrading EventStatus = "AFTER_TRADING" // Betting closed, the event awaits its result
+	EventStatusResulted     EventStatus = "RESULTED"      // The event was resulted and settled
+	EventStatusCancelled    EventStatus = "CANCELLED"     // The event was cancelled
+
+	EventStatusUnknown EventStatus = "UNKNOWN" // A status this library does not know
+)
+
+// eventStatuses lists the known event statuses
+var eventStatuses = map[EventStatus]bool{
+	EventStatusPreTrading: true, EventStatusTrading: true, EventStatusTradingLive: true, EventStatusInterrupted: true,
+	EventStatusAfterTrading: true, EventStatusResulted: true, EventStatusCancelled: true,
+}
+
+// UnmarshalJSON decodes an event status, mapping unknown values to EventStatusUnknown
+func (s *EventStatus) UnmarshalJSON(data []byte) error {
+	var value string
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+	*s = EventStatus(value)
+	if value != "" && !eventStatuses[*s] {
+		*s = EventStatusUnknown // Do not fail on statuses added after this library
+	}
+	return nil
+}
+
+// SelectionStatus defines the state of a selection. Values Cloudbet adds later decode as SelectionStatusUnknown
+type SelectionStatus string
+
+const (
+	SelectionStatusEnabled   SelectionStatus = "SELECTION_ENABLED"   // The selection can be bet on
+	SelectionStatusDisabled  SelectionStatus = "SELECTION_DISABLED"  // The selection is closed for betting
+	SelectionStatusSuspended SelectionStatus = "SELECTION_SUSPENDED" // Betting on the selection is paused
+
+	SelectionStatusUnknown SelectionStatus = "UNKNOWN" // A status this library does not know
+)
+
+// selectionStatuses lists the known selection statuses
+var selectionStatuses = map[SelectionStatus]bool{
+	SelectionStatusEnabled: true, SelectionStatusDisabled: true, SelectionStatusSuspended: true,
+}
+
+// UnmarshalJSON decodes a selection status, mapping unknown values to SelectionStatusUnknown
+func (s *SelectionStatus) UnmarshalJSON(data []byte) error {
+	var value string
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+	*s = SelectionStatus(value)
+	if value != "" && !selectionStatuses[*s] {
+		*s = SelectionStatusUnknown // Do not fail on statuses added after this library
+	}
+	return nil
+}
@@ -0,0 +1,64 @@
+package cloudbet
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+// TestStatusUnmarshal tests that known statuses decode as their constant and unknown ones as Unknown
+func TestStatusUnmarshal(t *testing.T) {
+	var bet PlaceBetResponse
+	if err := json.Unmarshal([]byte(`{"status":"HALF_WIN"}`), &bet); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if bet.Status != BetStatusHalfWin || !bet.Status.IsSettled() {
+		t.Fatalf("expected a settled HALF_WIN status, got %s", bet.Status)
+	}
+	if err := json.Unmarshal([]byte(`{"status":"SOMETHING_NEW"}`), &bet); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if bet.Status != BetStatusUnknown || bet.Status.IsSettled() {
+		t.Fatalf("expected BetStatusUnknown, got %s", bet.Status)
+	}
+
+	var event Event
+	if err := json.Unmarshal([]byte(`{"status":"TRADING_LIVE"}`), &event); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if event.Status != EventStatusTradingLive {
+		t.Fatalf("expected EventStatusTradingLive, got %s", event.Status)
+	}
+	if err := json.Unmarshal([]byte(`{"status":"POSTPONED_FOREVER"}`), &event); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if event.Status != EventStatusUnknown {
+		t.Fatalf("expected EventStatusUnknown, got %s", event.Status)
+	}
+
+	var selection Selections
+	if err := json.Unmarshal([]byte(`{"status":"SELECTION_SUSPENDED"}`), &selection); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if selection.Status != SelectionStatusSuspended {
+		t.Fatalf("expected SelectionStatusSuspended, got %s", selection.Status)
+	}
+	if err := json.Unmarshal([]byte(`{"status":"SELECTION_HIDDEN"}`), &selection); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if selection.Status != SelectionStatusUnknown {
+		t.Fatalf("expected SelectionStatusUnknown, got %s", selection.Status)
+	}
+}
+
+// TestRejectionStatuses tests that every code matched by a sentinel error decodes as a rejected bet status
+func TestRejectionStatuses(t *testing.T) {
+	for code := range codeErrors {
+		var status BetStatus
+		if err := json.Unmarshal([]byte(`"`+code+`"`), &status); err != nil {
+			t.Fatalf("%s: expected no error, got %v", code, err)
+		}
+		if status != BetStatus(code) || !status.IsRejected() || status.IsOpen() || status.IsSettled() {
+			t.Fatalf("%s: expected a rejected status, got %s", code, status)
+		}
+	}
+}
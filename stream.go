@@ -0,0 +1,216 @@
+package cloudbet
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	defaultStreamBackoff = 500 * time.Millisecond // First reconnection delay when retry is not configured
+	maxStreamBackoff     = 30 * time.Second       // Upper bound of the reconnection delay
+)
+
+// OddsUpdate is a price change pushed by the odds stream
+type OddsUpdate struct {
+	EventID   int             `json:"eventId"`   // ID of the event
+	MarketKey string          `json:"marketKey"` // Key of the market (e.g. soccer.match_odds)
+	Outcome   string          `json:"outcome"`   // Outcome of the selection
+	Params    string          `json:"params"`    // Params of the selection
+	Price     float64         `json:"price"`     // New price of the selection
+	Status    SelectionStatus `json:"status"`    // Status of the selection
+	Sequence  int             `json:"sequence"`  // Sequence number of the update
+}
+
+// StreamOdds connects to the Server-Sent Events odds feed of a sport and pushes price updates
+// on the returned channel until ctx is cancelled or the client closed, when the channel is closed. Dropped connections
+// are reopened with exponential backoff, starting from the WithRetry base delay when it is set, and resume
+// after the last sequence number received. Updates replayed on resume are not delivered twice.
+// The stream is not subject to the client timeout, see WithStreamIdleTimeout to detect silent connections
+func (c *APIClient) StreamOdds(ctx context.Context, sport SportKey) (<-chan OddsUpdate, error) {
+	ctx, cancel := c.withClose(ctx)          // Close stops the stream too
+	body, err := c.openStream(ctx, sport, 0) // Fail fast on errors such as an invalid API key
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+
+	updates := make(chan OddsUpdate)
+	go func() {
+		defer cancel()
+		c.runStream(ctx, sport, body, updates)
+	}()
+	return updates, nil
+}
+
+// WithStreamIdleTimeout makes StreamOdds reconnect when nothing, not even a heartbeat, has been
+// received for the given duration. Zero, the default, waits forever
+func WithStreamIdleTimeout(d time.Duration) Option {
+	return func(c *APIClient) {
+		c.streamIdleTimeout = d
+	}
+}
+
+// openStream opens a connection to the odds feed and returns its body, resuming after
+// lastSequence when it is set
+func (c *APIClient) openStream(ctx context.Context, sport SportKey, lastSequence int) (io.ReadCloser, error) {
+	if c.isClosed() {
+		return nil, ErrClosed // Return error if the client was closed
+	}
+	req, err := c.newRequest(ctx, "GET", c.oddsPath(oddsStreamPath)+"?sport="+url.QueryEscape(string(sport)), nil)
+	if err != nil {
+		return nil, err // Return error if request creation fails
+	}
+	req.Header.Set("Accept", "text/event-stream") // Ask for the event stream
+	req.Header.Del("Accept-Encoding")             // Events must not be buffered by a decompressor
+	if lastSequence > 0 {
+		req.Header.Set("Last-Event-ID", strconv.Itoa(lastSequence)) // Resume where the previous connection stopped
+	}
+
+	if c.limiter != nil {
+		if err := c.limiter.Wait(ctx); err != nil {
+			return nil, err // Return error if the context ends before a token is available
+		}
+	}
+
+	client := *c.Client
+	client.Timeout = 0     // The stream stays open, only ctx ends it
+	c.runRequestHooks(req) // Let callers decorate the request
+	start := time.Now()
+	resp, err := client.Do(req)
+	if c.metrics != nil {
+		c.observe("StreamOdds", start, resp, err)
+	}
+	if err != nil {
+		return nil, c.redactError(contextError(ctx, "failed to open odds stream", &NetworkError{Err: err})) // Return error if request fails
+	}
+	c.runResponseHooks(resp) // Let callers observe the response
+	if resp.StatusCode != http.StatusOK {
+		defer resp.Body.Close()
+		body, _ := io.ReadAll(resp.Body)
+		apiErr := newAPIError(resp, body)
+		apiErr.Message = c.redact(apiErr.Message)
+		return nil, fmt.Errorf("failed to open odds stream: %w", apiErr) // Return error if status is not OK
+	}
+	return resp.Body, nil
+}
+
+// runStream reads updates until ctx is done, reconnecting whenever the connection drops or idles
+func (c *APIClient) runStream(ctx context.Context, sport SportKey, body io.ReadCloser, updates chan<- OddsUpdate) {
+	defer close(updates)
+
+	var (
+		lastSequence  int                 // Highest sequence received, the resume point
+		eventSequence = make(map[int]int) // Last sequence delivered per event
+	)
+	deliver := func(update OddsUpdate) error {
+		if update.Sequence > 0 {
+			if update.Sequence <= eventSequence[update.EventID] {
+				return nil // Already delivered before the reconnection
+			}
+			eventSequence[update.EventID] = update.Sequence
+			lastSequence = max(lastSequence, update.Sequence)
+		}
+		select {
+		case updates <- update:
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+
+	baseDelay := c.retry.baseDelay
+	if baseDelay <= 0 {
+		baseDelay = defaultStreamBackoff
+	}
+	for {
+		err := c.readStream(body, deliver)
+		body.Close()
+		if ctx.Err() != nil {
+			return // The caller stopped the stream
+		}
+		c.logf("cloudbet: odds stream for %s dropped: %v", sport, err)
+
+		for delay := baseDelay; ; delay = min(delay*2, maxStreamBackoff) {
+			if sleepContext(ctx, delay) != nil {
+				return
+			}
+			if body, err = c.openStream(ctx, sport, lastSequence); err == nil {
+				break // Reconnected, the delay starts over on the next drop
+			}
+			c.logf("cloudbet: reconnecting odds stream for %s failed: %v", sport, err)
+		}
+	}
+}
+
+// readStream reads the events of one connection, closing it once the idle timeout passes without
+// any data so that runStream reconnects. Time spent delivering an update does not count as idle
+func (c *APIClient) readStream(body io.ReadCloser, deliver func(OddsUpdate) error) error {
+	if c.streamIdleTimeout <= 0 {
+		return readOddsEvents(body, func() {}, deliver)
+	}
+
+	var idle atomic.Bool
+	timer := time.AfterFunc(c.streamIdleTimeout, func() {
+		idle.Store(true)
+		body.Close() // Unblock the read
+	})
+	defer timer.Stop()
+
+	err := readOddsEvents(body, func() { timer.Reset(c.streamIdleTimeout) }, func(update OddsUpdate) error {
+		timer.Stop() // Waiting on a slow consumer is not idle
+		defer timer.Reset(c.streamIdleTimeout)
+		return deliver(update)
+	})
+	if idle.Load() {
+		return fmt.Errorf("no data received for %v", c.streamIdleTimeout)
+	}
+	return err
+}
+
+// readOddsEvents parses Server-Sent Events from r and delivers the odds updates they carry,
+// calling activity for every line received
+func readOddsEvents(r io.Reader, activity func(), deliver func(OddsUpdate) error) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024) // Allow large events
+
+	var event, data string
+	for scanner.Scan() {
+		line := scanner.Text()
+		activity()
+		switch {
+		case line == "":
+			if data != "" && (event == "" || event == "odds") {
+				var update OddsUpdate
+				if err := json.Unmarshal([]byte(data), &update); err != nil {
+					return fmt.Errorf("invalid odds update: %w", err)
+				}
+				if err := deliver(update); err != nil {
+					return err
+				}
+			}
+			event, data = "", "" // The blank line ends the event
+		case strings.HasPrefix(line, ":"):
+			// Comment lines are heartbeats
+		case strings.HasPrefix(line, "event:"):
+			event = strings.TrimSpace(strings.TrimPrefix(line, "event:"))
+		case strings.HasPrefix(line, "data:"):
+			if data != "" {
+				data += "\n"
+			}
+			data += strings.TrimPrefix(strings.TrimPrefix(line, "data:"), " ")
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+	return io.EOF // The server closed the stream
+}
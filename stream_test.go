@@ -0,0 +1,150 @@
+package cloudbet
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// TestStreamOdds tests that updates are delivered across a dropped connection
+func TestStreamOdds(t *testing.T) {
+	var connections atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/pub/v2/odds/stream" || r.Header.Get("Accept") != "text/event-stream" {
+			t.Errorf("unexpected request %s", r.URL)
+		}
+		n := connections.Add(1)
+		w.Header().Set("Content-Type", "text/event-stream")
+		fmt.Fprint(w, ": heartbeat\n\n")
+		fmt.Fprintf(w, "event: odds\ndata: {\"eventId\":1,\"marketKey\":\"soccer.match_odds\",\"outcome\":\"home\",\"price\":1.9,\"sequence\":%d}\n\n", n*10)
+		w.(http.Flusher).Flush()
+		if n > 1 {
+			<-r.Context().Done() // Keep the second connection open
+		}
+	}))
+	defer server.Close()
+
+	client := NewAPIClient(apikey, WithBaseURL(server.URL), WithRetry(1, time.Millisecond))
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	updates, err := client.StreamOdds(ctx, "soccer")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	for _, want := range []int{10, 20} {
+		select {
+		case update := <-updates:
+			if update.Sequence != want || update.Price != 1.9 || update.MarketKey != "soccer.match_odds" {
+				t.Fatalf("unexpected update %+v", update)
+			}
+		case <-time.After(2 * time.Second):
+			t.Fatalf("timed out waiting for update %d", want)
+		}
+	}
+
+	cancel()
+	for range updates {
+	}
+}
+
+// TestStreamOddsUnauthorized tests that connection errors are returned immediately
+func TestStreamOddsUnauthorized(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer server.Close()
+
+	client := NewAPIClient(apikey, WithBaseURL(server.URL))
+	if _, err := client.StreamOdds(context.Background(), "soccer"); err == nil {
+		t.Fatal("expected an error")
+	}
+}
+
+// TestStreamOddsIdleResume tests that a silent connection is reopened after the last sequence
+// and that updates replayed by the server are not delivered twice
+func TestStreamOddsIdleResume(t *testing.T) {
+	var connections atomic.Int32
+	lastEventIDs := make(chan string, 2)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := connections.Add(1)
+		lastEventIDs <- r.Header.Get("Last-Event-ID")
+		w.Header().Set("Content-Type", "text/event-stream")
+		fmt.Fprint(w, "data: {\"eventId\":1,\"outcome\":\"home\",\"price\":1.9,\"sequence\":5}\n\n")
+		if n > 1 {
+			fmt.Fprint(w, "data: {\"eventId\":1,\"outcome\":\"home\",\"price\":1.95,\"sequence\":6}\n\n")
+		}
+		w.(http.Flusher).Flush()
+		<-r.Context().Done() // Go silent without closing the connection
+	}))
+	defer server.Close()
+
+	client := NewAPIClient(apikey, WithBaseURL(server.URL), WithRetry(1, time.Millisecond), WithStreamIdleTimeout(100*time.Millisecond))
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	updates, err := client.StreamOdds(ctx, "soccer")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	for _, want := range []int{5, 6} {
+		select {
+		case update := <-updates:
+			if update.Sequence != want {
+				t.Fatalf("expected sequence %d, got %+v", want, update)
+			}
+		case <-time.After(2 * time.Second):
+			t.Fatalf("timed out waiting for sequence %d", want)
+		}
+	}
+	if first, second := <-lastEventIDs, <-lastEventIDs; first != "" || second != "5" {
+		t.Fatalf("expected to resume after sequence 5, got Last-Event-ID %q then %q", first, second)
+	}
+
+	cancel()
+	for range updates {
+	}
+}
+
+// TestStreamOddsSlowConsumer tests that a slow consumer does not make a busy connection look idle
+func TestStreamOddsSlowConsumer(t *testing.T) {
+	var connections atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		connections.Add(1)
+		w.Header().Set("Content-Type", "text/event-stream")
+		for sequence := 1; r.Context().Err() == nil; sequence++ {
+			fmt.Fprintf(w, "data: {\"eventId\":1,\"outcome\":\"home\",\"price\":1.9,\"sequence\":%d}\n\n", sequence)
+			w.(http.Flusher).Flush()
+			time.Sleep(20 * time.Millisecond) // Keep sending well within the idle timeout
+		}
+	}))
+	defer server.Close()
+
+	client := NewAPIClient(apikey, WithBaseURL(server.URL), WithRetry(1, time.Millisecond), WithStreamIdleTimeout(100*time.Millisecond))
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	updates, err := client.StreamOdds(ctx, "soccer")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	for i := 0; i < 3; i++ {
+		select {
+		case <-updates:
+			time.Sleep(300 * time.Millisecond) // Take longer than the idle timeout per update
+		case <-time.After(2 * time.Second):
+			t.Fatalf("timed out waiting for update %d", i)
+		}
+	}
+	if n := connections.Load(); n != 1 {
+		t.Fatalf("expected a single connection, got %d", n)
+	}
+
+	cancel()
+	for range updates {
+	}
+}
@@ -0,0 +1,19 @@
+package cloudbet
+
+import (
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/Flagon00/CloudbetClient/cloudbettest"
+)
+
+// newTestClient starts a test server running handler, or the cloudbettest fake API when handler is nil,
+// and returns a client pointed at it with a function closing the server
+func newTestClient(handler http.Handler, opts ...Option) (*APIClient, func()) {
+	if handler == nil {
+		handler = cloudbettest.Handler() // Serve the canned fixtures by default
+	}
+	server := httptest.NewServer(handler)
+	client := NewAPIClient("test-api-key", append([]Option{WithBaseURL(server.URL)}, opts...)...)
+	return client, server.Close
+}
@@ -0,0 +1,83 @@
+package cloudbet
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// CloudbetTime is a timestamp decoded leniently from Cloudbet responses. Missing, null, empty
+// and zero timestamps decode to the zero time instead of failing the whole response
+type CloudbetTime struct {
+	time.Time
+}
+
+// cloudbetTimeLayouts are the timestamp formats seen in Cloudbet responses, in UTC when no zone is given
+var cloudbetTimeLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05.999999999",
+	"2006-01-02 15:04:05",
+}
+
+// UnmarshalJSON decodes RFC 3339 strings, zone-less timestamps and unix seconds
+func (t *CloudbetTime) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		*t = CloudbetTime{}
+		return nil
+	}
+	if !bytes.HasPrefix(data, []byte(`"`)) {
+		seconds, err := strconv.ParseInt(string(data), 10, 64) // Some timestamps are unix seconds
+		if err != nil {
+			return fmt.Errorf("invalid timestamp %s: %w", data, err)
+		}
+		*t = CloudbetTime{}
+		if seconds > 0 {
+			t.Time = time.Unix(seconds, 0).UTC()
+		}
+		return nil
+	}
+
+	var value string
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+	parsed, err := parseCloudbetTime(value)
+	if err != nil {
+		return err
+	}
+	*t = CloudbetTime{Time: parsed}
+	return nil
+}
+
+// parseCloudbetTime parses a timestamp string in any of the Cloudbet layouts, returning it in UTC.
+// Missing timestamps parse to the zero time
+func parseCloudbetTime(value string) (time.Time, error) {
+	if value == "" || value == "0" {
+		return time.Time{}, nil // Missing timestamps decode to the zero time
+	}
+	for _, layout := range cloudbetTimeLayouts {
+		if parsed, err := time.ParseInLocation(layout, value, time.UTC); err == nil {
+			if parsed.IsZero() || parsed.Year() <= 1 {
+				return time.Time{}, nil
+			}
+			return parsed.UTC(), nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid timestamp %q", value)
+}
+
+// CreatedAt parses CreateTime into a UTC time, for ordering bets or measuring acceptance latency.
+// A bet without a creation time gives the zero time
+func (r PlaceBetResponse) CreatedAt() (time.Time, error) {
+	return parseCloudbetTime(r.CreateTime)
+}
+
+// MarshalJSON encodes the time as an RFC 3339 string, or null when it is zero
+func (t CloudbetTime) MarshalJSON() ([]byte, error) {
+	if t.IsZero() {
+		return []byte("null"), nil
+	}
+	return json.Marshal(t.UTC().Format(time.RFC3339Nano))
+}
@@ -0,0 +1,70 @@
+package cloudbet
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+// TestCloudbetTimeUnmarshal tests the accepted timestamp encodings
+func TestCloudbetTimeUnmarshal(t *testing.T) {
+	want := time.Date(2024, 5, 19, 15, 0, 0, 0, time.UTC)
+	tests := []struct {
+		input string
+		want  time.Time
+	}{
+		{`"2024-05-19T15:00:00Z"`, want},
+		{`"2024-05-19T17:00:00+02:00"`, want},
+		{`"2024-05-19T15:00:00"`, want},
+		{`"2024-05-19 15:00:00"`, want},
+		{`1716130800`, want},
+		{`""`, time.Time{}},
+		{`null`, time.Time{}},
+		{`"0001-01-01T00:00:00Z"`, time.Time{}},
+		{`0`, time.Time{}},
+	}
+	for _, tt := range tests {
+		var got CloudbetTime
+		if err := json.Unmarshal([]byte(tt.input), &got); err != nil {
+			t.Fatalf("%s: expected no error, got %v", tt.input, err)
+		}
+		if !got.Equal(tt.want) {
+			t.Fatalf("%s: expected %v, got %v", tt.input, tt.want, got.Time)
+		}
+	}
+
+	var got CloudbetTime
+	if err := json.Unmarshal([]byte(`"tomorrow"`), &got); err == nil {
+		t.Fatal("expected an error for a malformed timestamp")
+	}
+}
+
+// TestEventMissingTimestamps tests that an unresolved event without timestamps decodes
+func TestEventMissingTimestamps(t *testing.T) {
+	var event Event
+	if err := json.Unmarshal([]byte(`{"id":1,"cutoffTime":"2024-05-19T15:00:00Z","endTime":"","resultedTime":null}`), &event); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if event.CutoffTime.IsZero() || !event.EndTime.IsZero() || !event.ResultedTime.IsZero() {
+		t.Fatalf("unexpected timestamps %+v", event)
+	}
+}
+
+// TestPlaceBetResponseCreatedAt tests that CreatedAt parses the creation time in UTC
+func TestPlaceBetResponseCreatedAt(t *testing.T) {
+	bet := PlaceBetResponse{CreateTime: "2024-05-19T17:00:00.250+02:00"}
+	created, err := bet.CreatedAt()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if want := time.Date(2024, 5, 19, 15, 0, 0, 250_000_000, time.UTC); !created.Equal(want) || created.Location() != time.UTC {
+		t.Fatalf("expected %v, got %v", want, created)
+	}
+
+	if created, err := (PlaceBetResponse{}).CreatedAt(); err != nil || !created.IsZero() {
+		t.Fatalf("expected the zero time for a missing creation time, got %v, %v", created, err)
+	}
+	if _, err := (PlaceBetResponse{CreateTime: "yesterday"}).CreatedAt(); err == nil {
+		t.Fatalf("expected an error for an invalid creation time")
+	}
+}
@@ -0,0 +1,78 @@
+package cloudbet
+
+import (
+	"io"
+	"net/http"
+	"time"
+)
+
+// RequestTiming describes how long a call took, from sending its request to reading its whole response body
+type RequestTiming struct {
+	Total       time.Duration // From sending the first attempt to reading the body, retry delays included
+	LastAttempt time.Duration // From sending the final attempt to reading its body, Total without retries
+	Attempts    int           // Number of attempts sent, more than 1 when the call was retried
+}
+
+// LastRequestDuration returns the timing of the most recent call whose response body was read, e.g. for
+// ad-hoc latency checks. Calls that failed before a response was received are not recorded. With
+// concurrent calls it describes whichever finished last, use RawResponse.Timing or a Collector to time
+// a given call
+func (c *APIClient) LastRequestDuration() RequestTiming {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.lastTiming
+}
+
+// timedBody is a response body recording the timing of its call once it has been read or closed
+type timedBody struct {
+	io.ReadCloser
+	client       *APIClient
+	start        time.Time     // Time the first attempt was sent
+	attemptStart time.Time     // Time the final attempt was sent
+	timing       RequestTiming // Timing of the call, set by finish
+	finished     bool          // Whether the timing was recorded
+}
+
+// timeBody wraps the body of the final response of a call so its timing is recorded once read
+func (c *APIClient) timeBody(resp *http.Response, start, attemptStart time.Time, attempts int) {
+	resp.Body = &timedBody{ReadCloser: resp.Body, client: c, start: start, attemptStart: attemptStart, timing: RequestTiming{Attempts: attempts}}
+}
+
+// Read reads from the body, recording the timing when the end of the body is reached
+func (b *timedBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	if err != nil {
+		b.finish() // The body was fully read, or cannot be read further
+	}
+	return n, err
+}
+
+// Close closes the body, recording the timing if it was not read to the end
+func (b *timedBody) Close() error {
+	b.finish()
+	return b.ReadCloser.Close()
+}
+
+// finish records the timing of the call the first time it is called
+func (b *timedBody) finish() {
+	if b.finished {
+		return
+	}
+	b.finished = true
+
+	now := time.Now()
+	b.timing.Total, b.timing.LastAttempt = now.Sub(b.start), now.Sub(b.attemptStart)
+	b.client.mu.Lock()
+	b.client.lastTiming = b.timing
+	b.client.mu.Unlock()
+}
+
+// responseTiming returns the timing of a call whose response body was read, zero when it was not timed
+func responseTiming(resp *http.Response) RequestTiming {
+	body, ok := resp.Body.(*timedBody)
+	if !ok {
+		return RequestTiming{}
+	}
+	body.finish()
+	return body.timing
+}
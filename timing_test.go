@@ -0,0 +1,45 @@
+package cloudbet
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// TestRequestTiming tests that calls are timed until their body is read, in total and for the final attempt
+func TestRequestTiming(t *testing.T) {
+	const delay = 30 * time.Millisecond
+	var attempts atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if attempts.Add(1) == 1 {
+			time.Sleep(delay)
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte(`{"id":1,`))
+		w.(http.Flusher).Flush() // Send the headers, the rest of the body comes later
+		time.Sleep(delay)
+		w.Write([]byte(`"name":"A v B"}`))
+	}))
+	defer server.Close()
+
+	client := NewAPIClient(apikey, WithBaseURL(server.URL), WithRetry(2, time.Millisecond))
+
+	if timing := client.LastRequestDuration(); timing != (RequestTiming{}) {
+		t.Fatalf("expected no timing before the first call, got %+v", timing)
+	}
+	_, raw, err := client.GetEventWithRaw(context.Background(), "1")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	timing := raw.Timing
+	if timing.Attempts != 2 || timing.LastAttempt < delay || timing.Total < timing.LastAttempt+delay {
+		t.Fatalf("unexpected timing %+v", timing)
+	}
+	if last := client.LastRequestDuration(); last != timing {
+		t.Fatalf("expected LastRequestDuration to report %+v, got %+v", timing, last)
+	}
+}
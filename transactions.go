@@ -0,0 +1,91 @@
+package cloudbet
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// TxType defines the kind of a transaction
+type TxType string
+
+const (
+	TxTypeDeposit    TxType = "DEPOSIT"    // Funds deposited to the account
+	TxTypeWithdrawal TxType = "WITHDRAWAL" // Funds withdrawn from the account
+	TxTypeBet        TxType = "BET"        // Stake taken when a bet was placed
+	TxTypeSettlement TxType = "SETTLEMENT" // Return paid when a bet was settled
+	TxTypeRefund     TxType = "REFUND"     // Stake refunded on a rejected or voided bet
+)
+
+// Transaction is one entry of the account ledger, explaining a change of a balance
+type Transaction struct {
+	ID          string       `json:"id"`          // ID of the transaction
+	Type        TxType       `json:"type"`        // Kind of the transaction
+	Currency    string       `json:"currency"`    // Currency of the balance affected
+	Amount      Money        `json:"amount"`      // Change of the balance, negative for debits
+	Balance     Money        `json:"balance"`     // Balance after the transaction
+	ReferenceID string       `json:"referenceId"` // Reference ID of the bet, empty for deposits and withdrawals
+	Time        CloudbetTime `json:"createTime"`  // Time of the transaction
+}
+
+// TxParams defines the filters and pagination of a transactions query, zero values are not sent
+type TxParams struct {
+	Type     TxType    // Only return transactions of this type
+	Currency string    // Only return transactions in this currency
+	From     time.Time // Only return transactions made at or after this time
+	To       time.Time // Only return transactions made before this time
+	Limit    int       // Maximum number of transactions in the page
+	Offset   int       // Number of transactions to skip, use NextOffset of the previous page
+}
+
+// TxPage defines one page of the account transactions
+type TxPage struct {
+	Transactions []Transaction // Transactions of this page, newest first
+	Total        int           // Total number of transactions matching the filters
+	NextOffset   int           // Offset of the next page
+	HasMore      bool          // Whether more transactions are available after this page
+}
+
+// transactionsResponse defines the structure for the transactions response
+type transactionsResponse struct {
+	Transactions      []Transaction `json:"transactions"`      // Transactions of the page
+	TotalTransactions int           `json:"totalTransactions"` // Total number of transactions matching the filters
+}
+
+// GetTransactions retrieves one page of the account ledger matching the given filters: deposits,
+// withdrawals, stakes and settlements, explaining how the balances reached their current value
+func (c *APIClient) GetTransactions(ctx context.Context, params TxParams) (*TxPage, error) {
+	query := url.Values{}
+	if params.Type != "" {
+		query.Set("type", string(params.Type))
+	}
+	if params.Currency != "" {
+		query.Set("currency", params.Currency)
+	}
+	if !params.From.IsZero() {
+		query.Set("from", strconv.FormatInt(params.From.Unix(), 10)) // Time filters are unix timestamps
+	}
+	if !params.To.IsZero() {
+		query.Set("to", strconv.FormatInt(params.To.Unix(), 10))
+	}
+	if params.Limit > 0 {
+		query.Set("limit", strconv.Itoa(params.Limit))
+	}
+	if params.Offset > 0 {
+		query.Set("offset", strconv.Itoa(params.Offset))
+	}
+
+	var transactions transactionsResponse // Variable to hold the transactions response
+	if err := c.getJSON(ctx, "GetTransactions", "failed to get transactions", c.accountPath(accountTransactionsPath)+"?"+query.Encode(), &transactions); err != nil {
+		return nil, err // Return error if the request fails
+	}
+
+	nextOffset := params.Offset + len(transactions.Transactions)
+	return &TxPage{
+		Transactions: transactions.Transactions,
+		Total:        transactions.TotalTransactions,
+		NextOffset:   nextOffset,
+		HasMore:      len(transactions.Transactions) > 0 && nextOffset < transactions.TotalTransactions,
+	}, nil
+}
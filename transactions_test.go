@@ -0,0 +1,46 @@
+package cloudbet
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// TestGetTransactions tests that filters are sent, amounts decoded exactly and pagination computed
+func TestGetTransactions(t *testing.T) {
+	from := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		if r.URL.Path != "/pub/v1/account/transactions" || query.Get("type") != "SETTLEMENT" || query.Get("currency") != "PLAY_EUR" ||
+			query.Get("from") != "1714521600" || query.Get("to") != "" || query.Get("limit") != "2" || query.Get("offset") != "" {
+			t.Errorf("unexpected request %s", r.URL)
+		}
+		w.Write([]byte(`{"transactions":[
+			{"id":"t1","type":"SETTLEMENT","currency":"PLAY_EUR","amount":"19.1","balance":"119.10","referenceId":"ref-1","createTime":"2024-05-02T10:00:00Z"},
+			{"id":"t2","type":"SETTLEMENT","currency":"PLAY_EUR","amount":"0.30000000000000001","balance":"100"}],"totalTransactions":3}`))
+	}))
+	defer server.Close()
+
+	client := NewAPIClient(apikey, WithBaseURL(server.URL))
+
+	page, err := client.GetTransactions(context.Background(), TxParams{Type: TxTypeSettlement, Currency: "PLAY_EUR", From: from, Limit: 2})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(page.Transactions) != 2 {
+		t.Fatalf("unexpected transactions %+v", page.Transactions)
+	}
+	first := page.Transactions[0]
+	if first.Type != TxTypeSettlement || first.Amount.String() != "19.1" || first.Balance.String() != "119.1" || first.ReferenceID != "ref-1" ||
+		!first.Time.Equal(time.Date(2024, 5, 2, 10, 0, 0, 0, time.UTC)) {
+		t.Fatalf("unexpected transaction %+v", first)
+	}
+	if page.Transactions[1].Amount.String() != "0.30000000000000001" {
+		t.Fatalf("expected the exact amount, got %s", page.Transactions[1].Amount)
+	}
+	if page.NextOffset != 2 || !page.HasMore || page.Total != 3 {
+		t.Fatalf("unexpected pagination %+v", page)
+	}
+}
@@ -0,0 +1,92 @@
+package cloudbet
+
+import (
+	"crypto/tls"
+	"net/http"
+	"time"
+)
+
+// Connection pooling defaults of the transport created by NewAPIClient. The standard library keeps only
+// 2 idle connections per host, so concurrent calls (e.g. GetEvents) would keep opening new connections
+const (
+	DefaultMaxIdleConns        = 100              // Idle connections kept across all hosts
+	DefaultMaxIdleConnsPerHost = 32               // Idle connections kept to the Cloudbet API
+	DefaultIdleConnTimeout     = 90 * time.Second // How long an idle connection is kept
+)
+
+// newDefaultTransport returns the transport used by NewAPIClient, the standard library defaults
+// (proxy from the environment, dial and TLS timeouts, HTTP/2) with larger connection pools
+func newDefaultTransport() *http.Transport {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.MaxIdleConns = DefaultMaxIdleConns
+	transport.MaxIdleConnsPerHost = DefaultMaxIdleConnsPerHost
+	transport.IdleConnTimeout = DefaultIdleConnTimeout
+	return transport
+}
+
+// WithMaxIdleConnsPerHost sets how many idle connections to the Cloudbet API are kept for reuse,
+// raise it above DefaultMaxIdleConnsPerHost for scanners running more concurrent calls. It only applies
+// to an *http.Transport, which is copied so a caller supplied one is never mutated
+func WithMaxIdleConnsPerHost(n int) Option {
+	return func(c *APIClient) {
+		c.tuneTransport(func(transport *http.Transport) {
+			transport.MaxIdleConnsPerHost = n
+			if transport.MaxIdleConns > 0 {
+				transport.MaxIdleConns = max(transport.MaxIdleConns, n) // The global pool must hold the per host one
+			}
+		})
+	}
+}
+
+// WithInsecureSkipVerify disables the verification of TLS certificates, to test against a local mock
+// such as httptest.NewTLSServer. FOR TESTING ONLY: anyone on the network path can then read and
+// alter requests, including the API key and bets. A warning is logged when a logger is set.
+// It only applies to an *http.Transport: it has no effect on a custom round tripper, and a later
+// WithHTTPClient or WithRoundTripper replaces it. A warning is logged when it had no effect
+func WithInsecureSkipVerify() Option {
+	return func(c *APIClient) {
+		c.insecureSkipVerify = true
+		c.tuneTransport(func(transport *http.Transport) {
+			if transport.TLSClientConfig == nil {
+				transport.TLSClientConfig = &tls.Config{}
+			}
+			transport.TLSClientConfig.InsecureSkipVerify = true
+		})
+	}
+}
+
+// skipsVerify reports whether the transport of the client does not verify TLS certificates
+func (c *APIClient) skipsVerify() bool {
+	transport, ok := c.Client.Transport.(*http.Transport)
+	return ok && transport.TLSClientConfig != nil && transport.TLSClientConfig.InsecureSkipVerify
+}
+
+// warnInsecure logs whether TLS certificates go unverified, or WithInsecureSkipVerify had no effect
+func (c *APIClient) warnInsecure() {
+	switch {
+	case c.skipsVerify():
+		c.logf("cloudbet: WARNING: TLS certificate verification is disabled, use WithInsecureSkipVerify for testing only")
+	case c.insecureSkipVerify:
+		c.logf("cloudbet: WithInsecureSkipVerify has no effect on a custom round tripper, TLS certificates are verified")
+	}
+}
+
+// tuneTransport applies tune to a copy of the *http.Transport of the client, so a caller supplied one is
+// never mutated. It reports false, leaving the client as is, when the transport is a custom round tripper
+func (c *APIClient) tuneTransport(tune func(*http.Transport)) bool {
+	base := c.Client.Transport
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	transport, ok := base.(*http.Transport)
+	if !ok {
+		return false // Custom round trippers manage their own connections
+	}
+	transport = transport.Clone() // Clone copies the TLS config too
+	tune(transport)
+
+	client := *c.Client // Copy the client so a caller supplied one is never mutated
+	client.Transport = transport
+	c.Client = &client
+	return true
+}
@@ -0,0 +1,100 @@
+package cloudbet
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// TestTransportDefaults tests the pooling defaults and that tuning never mutates a caller supplied client
+func TestTransportDefaults(t *testing.T) {
+	transport, ok := NewAPIClient(apikey).Client.Transport.(*http.Transport)
+	if !ok || transport.MaxIdleConnsPerHost != DefaultMaxIdleConnsPerHost || transport.MaxIdleConns != DefaultMaxIdleConns {
+		t.Fatalf("unexpected default transport %+v", transport)
+	}
+
+	httpClient := &http.Client{Transport: &http.Transport{MaxIdleConns: 10}}
+	client := NewAPIClient(apikey, WithHTTPClient(httpClient), WithMaxIdleConnsPerHost(64))
+	transport = client.Client.Transport.(*http.Transport)
+	if transport.MaxIdleConnsPerHost != 64 || transport.MaxIdleConns != 64 {
+		t.Fatalf("unexpected tuned transport %+v", transport)
+	}
+	if original := httpClient.Transport.(*http.Transport); original.MaxIdleConnsPerHost != 0 || original.MaxIdleConns != 10 {
+		t.Fatalf("expected the supplied transport to be left untouched, got %+v", original)
+	}
+}
+
+// BenchmarkConcurrentGetEvent compares the standard library transport, keeping 2 idle connections per host,
+// with the default transport of the client when 32 calls per CPU run concurrently. The gap grows with the
+// latency of new connections, a local server without TLS is the best case for the standard transport
+func BenchmarkConcurrentGetEvent(b *testing.B) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"id":1,"name":"Arsenal V Chelsea"}`))
+	}))
+	defer server.Close()
+
+	benchmarks := []struct {
+		name string
+		opts []Option
+	}{
+		{"standard", []Option{WithHTTPClient(&http.Client{Transport: http.DefaultTransport.(*http.Transport).Clone()})}},
+		{"tuned", nil},
+	}
+	for _, bm := range benchmarks {
+		b.Run(bm.name, func(b *testing.B) {
+			client := NewAPIClient(apikey, append([]Option{WithBaseURL(server.URL)}, bm.opts...)...)
+			defer client.Close()
+
+			b.SetParallelism(32)
+			b.ResetTimer()
+			b.RunParallel(func(pb *testing.PB) {
+				for pb.Next() {
+					if _, err := client.GetEventJSON(context.Background(), "1"); err != nil {
+						b.Error(err)
+						return
+					}
+				}
+			})
+		})
+	}
+}
+
+// TestWithInsecureSkipVerify tests that a self-signed test server is accepted and a warning logged
+func TestWithInsecureSkipVerify(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"id":1}`))
+	}))
+	defer server.Close()
+
+	if _, err := NewAPIClient(apikey, WithBaseURL(server.URL)).GetEventJSON(context.Background(), "1"); err == nil {
+		t.Fatalf("expected the self-signed certificate to be rejected by default")
+	}
+
+	logger := &recordingLogger{}
+	client := NewAPIClient(apikey, WithBaseURL(server.URL), WithInsecureSkipVerify(), WithLogger(logger))
+	if event, err := client.GetEventJSON(context.Background(), "1"); err != nil || event.ID != 1 {
+		t.Fatalf("expected the event, got %+v, %v", event, err)
+	}
+	if len(logger.lines) == 0 || !strings.Contains(logger.lines[0], "TLS certificate verification is disabled") {
+		t.Fatalf("expected a warning, got %q", logger.lines)
+	}
+	if config := http.DefaultTransport.(*http.Transport).TLSClientConfig; config != nil && config.InsecureSkipVerify {
+		t.Fatalf("expected the default transport to be left untouched")
+	}
+
+	logger = &recordingLogger{}
+	NewAPIClient(apikey, WithInsecureSkipVerify(), WithHTTPClient(&http.Client{}), WithLogger(logger))
+	if len(logger.lines) != 0 {
+		t.Fatalf("expected no warning once the transport was replaced, got %q", logger.lines)
+	}
+
+	logger = &recordingLogger{}
+	custom := roundTripperFunc(func(req *http.Request) (*http.Response, error) { return nil, errors.New("unused") })
+	NewAPIClient(apikey, WithRoundTripper(custom), WithInsecureSkipVerify(), WithLogger(logger))
+	if len(logger.lines) == 0 || !strings.Contains(logger.lines[0], "no effect") {
+		t.Fatalf("expected a warning that the option had no effect, got %q", logger.lines)
+	}
+}
@@ -0,0 +1,76 @@
+package cloudbet
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// WebhookSignatureHeader is the header carrying the hex encoded HMAC-SHA256 of the webhook body
+const WebhookSignatureHeader = "X-Cloudbet-Signature"
+
+// maxWebhookBytes bounds the size of a webhook body
+const maxWebhookBytes = 1 << 20
+
+// WebhookEventType is the kind of a webhook event
+type WebhookEventType string
+
+// Webhook event types
+const (
+	WebhookBetUpdated WebhookEventType = "bet.updated" // The status of a bet changed, e.g. it was accepted
+	WebhookBetSettled WebhookEventType = "bet.settled" // A bet was settled, its status is final
+)
+
+// WebhookEvent is a bet update pushed by a Cloudbet webhook
+type WebhookEvent struct {
+	ID   string           `json:"id"`   // Unique ID of the event, to ignore redeliveries
+	Type WebhookEventType `json:"type"` // Kind of the event
+	Time CloudbetTime     `json:"time"` // Time the event occurred
+	Bet  PlaceBetResponse `json:"bet"`  // Bet the event is about, as GetBetStatus would return it
+}
+
+// WithWebhookSecret sets the secret used by ParseWebhook to verify webhook signatures
+func WithWebhookSecret(secret string) Option {
+	return func(c *APIClient) {
+		c.webhookSecret = []byte(secret)
+	}
+}
+
+// ParseWebhook verifies the signature of a webhook request and decodes its body. The signature
+// is the hex encoded HMAC-SHA256 of the body keyed by the WithWebhookSecret secret, optionally
+// prefixed by "sha256=", and is compared in constant time. Requests with a missing or wrong
+// signature return an error matching ErrInvalidSignature
+func (c *APIClient) ParseWebhook(r *http.Request) (*WebhookEvent, error) {
+	if len(c.webhookSecret) == 0 {
+		return nil, fmt.Errorf("failed to parse webhook: no webhook secret configured") // Never accept unverified webhooks
+	}
+
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxWebhookBytes+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read webhook: %w", err) // Return error if the body cannot be read
+	}
+	if len(body) > maxWebhookBytes {
+		return nil, fmt.Errorf("failed to read webhook: body larger than %d bytes", maxWebhookBytes)
+	}
+
+	signature, err := hex.DecodeString(strings.TrimPrefix(r.Header.Get(WebhookSignatureHeader), "sha256="))
+	if err != nil || len(signature) == 0 {
+		return nil, fmt.Errorf("failed to parse webhook: %w", ErrInvalidSignature) // Return error if the signature is missing or malformed
+	}
+	mac := hmac.New(sha256.New, c.webhookSecret)
+	mac.Write(body)
+	if !hmac.Equal(signature, mac.Sum(nil)) {
+		return nil, fmt.Errorf("failed to parse webhook: %w", ErrInvalidSignature) // Return error if the body was not signed with the secret
+	}
+
+	var event WebhookEvent // Variable to hold the parsed webhook event
+	if err := json.Unmarshal(body, &event); err != nil {
+		return nil, fmt.Errorf("failed to parse webhook: %w", err) // Return error if the body is not a webhook event
+	}
+	return &event, nil
+}
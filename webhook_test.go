@@ -0,0 +1,47 @@
+package cloudbet
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// TestParseWebhook tests that webhooks are decoded only when their signature is valid
+func TestParseWebhook(t *testing.T) {
+	const secret = "webhook-secret"
+	body := `{"id":"evt-1","type":"bet.settled","time":"2024-05-19T17:00:00Z","bet":{"referenceId":"ref-1","status":"WIN","returnAmount":"2.5"}}`
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(body))
+	signature := hex.EncodeToString(mac.Sum(nil))
+
+	client := NewAPIClient(apikey, WithWebhookSecret(secret))
+	for _, header := range []string{signature, "sha256=" + signature} {
+		req := httptest.NewRequest("POST", "/webhook", strings.NewReader(body))
+		req.Header.Set(WebhookSignatureHeader, header)
+		event, err := client.ParseWebhook(req)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if event.Type != WebhookBetSettled || event.Bet.ReferenceID != "ref-1" || !event.Bet.Status.IsSettled() || event.Bet.ReturnAmount.String() != "2.5" {
+			t.Fatalf("unexpected event %+v", event)
+		}
+	}
+
+	for _, header := range []string{"", "not-hex", signature[:len(signature)-2] + "00"} {
+		req := httptest.NewRequest("POST", "/webhook", strings.NewReader(body))
+		req.Header.Set(WebhookSignatureHeader, header)
+		if _, err := client.ParseWebhook(req); !errors.Is(err, ErrInvalidSignature) {
+			t.Fatalf("signature %q: expected ErrInvalidSignature, got %v", header, err)
+		}
+	}
+
+	req := httptest.NewRequest("POST", "/webhook", strings.NewReader(body))
+	req.Header.Set(WebhookSignatureHeader, signature)
+	if _, err := NewAPIClient(apikey).ParseWebhook(req); err == nil {
+		t.Fatalf("expected an error without a webhook secret")
+	}
+}